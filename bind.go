@@ -0,0 +1,58 @@
+package pagination
+
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PaginationQuery binds offset and limit url query parameters with gin, see Bind
+type PaginationQuery struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// Bind binds offset and limit from url query with c.ShouldBindQuery, absent parameters being set to package defaults,
+// and validates them as GetFromURLQuery does, with the package max limit and zero limit policy
+// The bound values are then updated to the returned pagination
+// Returns a BadRequestValueError if a parameter is not an integer, an empty one included, or is invalid,
+// a LimitTooLargeError if it is rejected over max
+func (q *PaginationQuery) Bind(c *gin.Context) (Pagination, error) {
+	// gin binds an empty value as 0, so values are checked first to reject them as GetFromURLQuery does
+	if key, err := unboundKey(c); err != nil {
+		return Pagination{}, BadRequestValueError{Key: key, Err: err}
+	}
+
+	q.Offset, q.Limit = CurrentDefaultOffset(), CurrentDefaultLimit()
+	if err := c.ShouldBindQuery(q); err != nil {
+		return Pagination{}, BadRequestValueError{Err: err}
+	}
+
+	bound := url.Values{}
+	requested := c.Request.URL.Query()
+	for key, value := range map[string]int{defaultQueryKeys.Offset: q.Offset, defaultQueryKeys.Limit: q.Limit} {
+		if requested.Has(key) {
+			bound.Set(key, strconv.Itoa(value))
+		}
+	}
+
+	page, err := getFromQueryWithOptions(bound, DefaultOptions())
+	if err != nil {
+		return Pagination{}, err
+	}
+	q.Offset, q.Limit = page.Offset, page.Limit
+	return page, nil
+}
+
+// unboundKey returns the first pagination key of url query whose value is not an integer, with its parse error
+func unboundKey(c *gin.Context) (string, error) {
+	for _, key := range []string{defaultQueryKeys.Offset, defaultQueryKeys.Limit} {
+		if value, ok := c.GetQuery(key); ok {
+			if _, err := strconv.Atoi(value); err != nil {
+				return key, err
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,83 @@
+package pagination
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginationQueryBind(t *testing.T) {
+	tests := map[string]struct {
+		url        string
+		maxLimit   int
+		zeroLimit  ZeroLimitPolicy
+		want       Pagination
+		wantErrKey string
+	}{
+		"nominal": {
+			url:  "/?offset=20&limit=10",
+			want: Pagination{Offset: 20, Limit: 10},
+		},
+		"when params are absent, returns defaults": {
+			url:  "/",
+			want: Pagination{Offset: DefaultOffset, Limit: DefaultLimit500},
+		},
+		"when limit is not numeric, returns error": {
+			url:        "/?offset=20&limit=pouet",
+			wantErrKey: "limit",
+		},
+		"when offset is not numeric, returns error": {
+			url:        "/?offset=pouet",
+			wantErrKey: "offset",
+		},
+		"when limit is empty, returns error": {
+			url:        "/?limit=",
+			wantErrKey: "limit",
+		},
+		"when offset is empty, returns error": {
+			url:        "/?offset=&limit=10",
+			wantErrKey: "offset",
+		},
+		"when offset is negative, returns error": {
+			url:        "/?offset=-1",
+			wantErrKey: "offset",
+		},
+		"when limit exceeds package max, clamps it": {
+			url:      "/?limit=1000",
+			maxLimit: 100,
+			want:     Pagination{Offset: 0, Limit: 100},
+		},
+		"when limit is unbounded with package max, clamps it": {
+			url:      "/?limit=0",
+			maxLimit: 100,
+			want:     Pagination{Offset: 0, Limit: 100},
+		},
+		"when limit is 0, applies package zero limit policy": {
+			url:       "/?limit=0",
+			zeroLimit: ZeroLimitEmpty,
+			want:      Pagination{Offset: 0, Limit: 0, EmptyPage: true},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			restoreDefaults(t)
+			require.NoError(t, SetMaxLimit(tt.maxLimit))
+			require.NoError(t, SetZeroLimitPolicy(tt.zeroLimit))
+
+			var query PaginationQuery
+			page, err := query.Bind(newQueryContext(tt.url))
+
+			assert.Equal(t, tt.want, page)
+			if tt.wantErrKey == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var valueErr BadRequestValueError
+			require.ErrorAs(t, err, &valueErr)
+			assert.Equal(t, tt.wantErrKey, valueErr.Key)
+			assert.Equal(t, http.StatusBadRequest, HTTPStatus(err))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// contextKey is the type of the key holding pagination in a request context
+type contextKey struct{}
+
+// GetFromChiRequest gets page (limit and offset) from url query of a chi request
+func GetFromChiRequest(r *http.Request) (Pagination, error) {
+	return GetFromRequest(r)
+}
+
+// ChiMiddleware parses pagination from url query and stores it in the request context, see FromContext
+// Responds 400 with a ResponseError body if pagination cannot be parsed
+func ChiMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, err := GetFromChiRequest(r)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(NewResponseError(err))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, page)))
+	})
+}
+
+// FromContext returns pagination stored in ctx by ChiMiddleware
+func FromContext(ctx context.Context) (Pagination, bool) {
+	page, ok := ctx.Value(contextKey{}).(Pagination)
+	return page, ok
+}
@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChiMiddleware(t *testing.T) {
+	t.Run("when pagination is valid, stores it in request context", func(t *testing.T) {
+		var page Pagination
+		var found bool
+		handler := ChiMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			page, found = FromContext(r.Context())
+		}))
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/?offset=10&limit=20", nil))
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.True(t, found)
+		assert.Equal(t, Pagination{Offset: 10, Limit: 20}, page)
+	})
+
+	t.Run("when pagination is invalid, responds 400 with error body", func(t *testing.T) {
+		called := false
+		handler := ChiMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/?limit=pouet", nil))
+
+		assert.False(t, called)
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+		var body ResponseError
+		require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+		assert.Contains(t, body.Message, "limit")
+		assert.Equal(t, CodeBadRequestValue, body.Code)
+	})
+}
+
+func TestFromContext(t *testing.T) {
+	t.Run("when context has no pagination, returns false", func(t *testing.T) {
+		page, found := FromContext(context.Background())
+
+		assert.False(t, found)
+		assert.Equal(t, Pagination{}, page)
+	})
+}
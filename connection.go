@@ -0,0 +1,52 @@
+package pagination
+
+// Connection describes a GraphQL Relay cursor connection built from an offset pagination
+type Connection[T any] struct {
+	Edges      []Edge[T] `json:"edges"`
+	PageInfo   PageInfo  `json:"pageInfo"`
+	TotalCount int64     `json:"totalCount"`
+}
+
+// Edge wraps an element of a Connection with its cursor
+type Edge[T any] struct {
+	Node   T      `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+// PageInfo describes the page held by a Connection, cursors being nil when it has no edges
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+// BuildConnection builds a Relay connection from data of page among total rows
+// The cursor of each edge is the opaque encoding of its offset, see DecodeConnectionCursor
+func BuildConnection[T any](data []T, p Pagination, total int64) Connection[T] {
+	edges := make([]Edge[T], 0, len(data))
+	for i, value := range data {
+		edges = append(edges, Edge[T]{Node: value, Cursor: encodeOffsetToken(p.Offset + i)})
+	}
+
+	info := PageInfo{
+		HasNextPage:     int64(p.Offset+len(data)) < total,
+		HasPreviousPage: p.Offset > 0,
+	}
+	if len(edges) > 0 {
+		info.StartCursor = &edges[0].Cursor
+		info.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return Connection[T]{Edges: edges, PageInfo: info, TotalCount: total}
+}
+
+// DecodeConnectionCursor returns the offset of the edge of cursor built by BuildConnection
+// Returns a BadRequestValueError if cursor is malformed
+func DecodeConnectionCursor(cursor string) (int, error) {
+	offset, err := decodeOffsetToken(cursor)
+	if err != nil {
+		return 0, BadRequestValueError{Key: "cursor", Err: err}
+	}
+	return offset, nil
+}
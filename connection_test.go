@@ -0,0 +1,72 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildConnection(t *testing.T) {
+	t.Run("edge cursors decode to element offsets", func(t *testing.T) {
+		connection := BuildConnection([]string{"a", "b", "c"}, Pagination{Offset: 10, Limit: 3}, 20)
+
+		require.Len(t, connection.Edges, 3)
+		for i, edge := range connection.Edges {
+			offset, err := DecodeConnectionCursor(edge.Cursor)
+			require.NoError(t, err)
+			assert.Equal(t, 10+i, offset)
+		}
+		assert.Equal(t, "a", connection.Edges[0].Node)
+		assert.Equal(t, int64(20), connection.TotalCount)
+		assert.Equal(t, connection.Edges[0].Cursor, *connection.PageInfo.StartCursor)
+		assert.Equal(t, connection.Edges[2].Cursor, *connection.PageInfo.EndCursor)
+	})
+
+	t.Run("hasNextPage and hasPreviousPage", func(t *testing.T) {
+		tests := map[string]struct {
+			page         Pagination
+			data         []string
+			wantNext     bool
+			wantPrevious bool
+		}{
+			"first page":  {page: Pagination{Offset: 0, Limit: 2}, data: []string{"a", "b"}, wantNext: true},
+			"middle page": {page: Pagination{Offset: 2, Limit: 2}, data: []string{"c", "d"}, wantNext: true, wantPrevious: true},
+			"last page":   {page: Pagination{Offset: 4, Limit: 2}, data: []string{"e"}, wantPrevious: true},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				connection := BuildConnection(tt.data, tt.page, 5)
+
+				assert.Equal(t, tt.wantNext, connection.PageInfo.HasNextPage)
+				assert.Equal(t, tt.wantPrevious, connection.PageInfo.HasPreviousPage)
+			})
+		}
+	})
+
+	t.Run("empty connection has null cursors", func(t *testing.T) {
+		out, err := json.Marshal(BuildConnection([]string{}, Pagination{Offset: 0, Limit: 10}, 0))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"edges":[],"pageInfo":{"hasNextPage":false,"hasPreviousPage":false,"startCursor":null,"endCursor":null},"totalCount":0}`, string(out))
+	})
+
+	t.Run("malformed cursor returns error", func(t *testing.T) {
+		_, err := DecodeConnectionCursor("%%%")
+
+		var valueErr BadRequestValueError
+		require.ErrorAs(t, err, &valueErr)
+		assert.Equal(t, "cursor", valueErr.Key)
+	})
+
+	t.Run("cursor over MaxOffset returns error", func(t *testing.T) {
+		_, err := DecodeConnectionCursor(base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(MaxOffset+1, 10))))
+
+		var valueErr BadRequestValueError
+		require.ErrorAs(t, err, &valueErr)
+		assert.Equal(t, "cursor", valueErr.Key)
+	})
+}
@@ -1,7 +1,15 @@
 package pagination
 
+import "math"
+
 // DefaultOffset is used for default valid on pagination page_items_to_skip
 const DefaultOffset = 0
 
 // DefaultLimit500 is used for default valid on pagination page_size
 const DefaultLimit500 = 500
+
+// MaxOffset is the highest offset accepted, so that offset arithmetic cannot overflow int even on 32-bit platforms
+const MaxOffset = math.MaxInt32
+
+// TotalUnknown is the Pageable total of sources which cannot cheaply count their rows
+const TotalUnknown = -1
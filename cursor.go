@@ -0,0 +1,75 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CursorField is a sort column of a keyset cursor with the value of the last row returned for it
+// Columns are written as is in SQL, they must never come from user input
+type CursorField struct {
+	Column string
+	Value  interface{}
+}
+
+// EncodeCursor encodes values of the sort columns of the last row returned, tiebreaker included, into an opaque url safe cursor
+// Only values are encoded, columns being given back by the server to DecodeCursor
+func EncodeCursor(values ...interface{}) (string, error) {
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// DecodeCursor decodes cursor built by EncodeCursor, pairing its values with columns in order
+// Numbers are decoded as json.Number so that large integers keep their precision
+// Returns a BadRequestValueError if cursor is malformed or does not hold one value per column
+func DecodeCursor(cursor string, columns ...string) ([]CursorField, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, BadRequestValueError{Key: "cursor", Err: fmt.Errorf("malformed cursor: %w", err)}
+	}
+
+	var values []interface{}
+	decoder := json.NewDecoder(bytes.NewReader(decoded))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, BadRequestValueError{Key: "cursor", Err: fmt.Errorf("malformed cursor: %w", err)}
+	}
+
+	if len(values) != len(columns) {
+		return nil, BadRequestValueError{
+			Key: "cursor",
+			Err: fmt.Errorf("malformed cursor: %d values for %d columns", len(values), len(columns)),
+		}
+	}
+
+	fields := make([]CursorField, 0, len(columns))
+	for i, column := range columns {
+		fields = append(fields, CursorField{Column: column, Value: values[i]})
+	}
+	return fields, nil
+}
+
+// CursorWhere returns the keyset predicate selecting rows after fields in ascending order, e.g. "(created_at, id) > (?, ?)",
+// with its arguments. The last field should be a unique tiebreaker so that rows sharing sort values are neither skipped nor repeated
+// Returns an empty predicate and no arguments if there is no field, i.e. for the first page
+func CursorWhere(fields []CursorField) (string, []interface{}) {
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	columns := make([]string, 0, len(fields))
+	placeholders := make([]string, 0, len(fields))
+	args := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, field.Column)
+		placeholders = append(placeholders, "?")
+		args = append(args, field.Value)
+	}
+	return fmt.Sprintf("(%s) > (%s)", strings.Join(columns, ", "), strings.Join(placeholders, ", ")), args
+}
@@ -0,0 +1,53 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Run("two-column cursor round trip gives keyset predicate", func(t *testing.T) {
+		cursor, err := EncodeCursor("2023-01-02T15:04:05Z", int64(9007199254740993))
+		require.NoError(t, err)
+
+		fields, err := DecodeCursor(cursor, "created_at", "id")
+		require.NoError(t, err)
+		assert.Equal(t, []CursorField{
+			{Column: "created_at", Value: "2023-01-02T15:04:05Z"},
+			{Column: "id", Value: json.Number("9007199254740993")},
+		}, fields)
+
+		predicate, args := CursorWhere(fields)
+		assert.Equal(t, "(created_at, id) > (?, ?)", predicate)
+		assert.Equal(t, []interface{}{"2023-01-02T15:04:05Z", json.Number("9007199254740993")}, args)
+	})
+
+	t.Run("no field gives empty predicate", func(t *testing.T) {
+		predicate, args := CursorWhere(nil)
+
+		assert.Empty(t, predicate)
+		assert.Nil(t, args)
+	})
+
+	t.Run("malformed cursors are rejected", func(t *testing.T) {
+		mismatch, err := EncodeCursor(1)
+		require.NoError(t, err)
+
+		for name, cursor := range map[string]string{
+			"not base64":           "!!!",
+			"not JSON":             base64.RawURLEncoding.EncodeToString([]byte("pouet")),
+			"not an array":         base64.RawURLEncoding.EncodeToString([]byte(`{"id":1}`)),
+			"column count differs": mismatch,
+		} {
+			_, err := DecodeCursor(cursor, "created_at", "id")
+
+			var valueErr BadRequestValueError
+			require.ErrorAs(t, err, &valueErr, name)
+			assert.Equal(t, "cursor", valueErr.Key, name)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package pagination
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// defaultLimit and defaultOffset are the package defaults used when pagination is not requested
+// They are meant to be configured once at startup and are not safe for concurrent modification
+var (
+	defaultLimit  = DefaultLimit500
+	defaultOffset = DefaultOffset
+	maxLimit      = 0
+	zeroLimit     = ZeroLimitUnbounded
+)
+
+// SetDefaultLimit sets the limit used when none is requested, DefaultLimit500 being the initial value
+func SetDefaultLimit(limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("default limit (%d) cannot be negative", limit)
+	}
+	defaultLimit = limit
+	return nil
+}
+
+// SetDefaultOffset sets the offset used when none is requested, DefaultOffset being the initial value
+func SetDefaultOffset(offset int) error {
+	if offset < 0 {
+		return fmt.Errorf("default offset (%d) cannot be negative", offset)
+	}
+	if offset > MaxOffset {
+		return fmt.Errorf("default offset (%d) cannot exceed %d", offset, MaxOffset)
+	}
+	defaultOffset = offset
+	return nil
+}
+
+// SetMaxLimit sets the highest limit allowed by GetFromURLQuery, GetFromRequest, GetFromJSONBody, GetFromHeaders and Middleware,
+// higher requested limits, unbounded ones included, being lowered to it
+// A max limit of 0, the initial value, means no maximum
+func SetMaxLimit(limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("max limit (%d) cannot be negative", limit)
+	}
+	maxLimit = limit
+	return nil
+}
+
+// SetZeroLimitPolicy sets how GetFromURLQuery, GetFromRequest, GetFromJSONBody, GetFromHeaders, Middleware and
+// PaginationQuery.Bind handle an explicit limit=0, ZeroLimitUnbounded being the initial value
+func SetZeroLimitPolicy(policy ZeroLimitPolicy) error {
+	if policy < ZeroLimitUnbounded || policy > ZeroLimitEmpty {
+		return fmt.Errorf("zero limit policy (%d) is unknown", policy)
+	}
+	zeroLimit = policy
+	return nil
+}
+
+// CurrentZeroLimitPolicy returns how an explicit limit=0 is handled
+func CurrentZeroLimitPolicy() ZeroLimitPolicy {
+	return zeroLimit
+}
+
+// CurrentMaxLimit returns the highest limit allowed, 0 meaning no maximum
+func CurrentMaxLimit() int {
+	return maxLimit
+}
+
+// CurrentDefaultLimit returns the limit used when none is requested
+func CurrentDefaultLimit() int {
+	return defaultLimit
+}
+
+// CurrentDefaultOffset returns the offset used when none is requested
+func CurrentDefaultOffset() int {
+	return defaultOffset
+}
+
+// Environment variables read by LoadDefaultsFromEnv
+const (
+	EnvDefaultLimit  = "PAGINATION_DEFAULT_LIMIT"
+	EnvMaxLimit      = "PAGINATION_MAX_LIMIT"
+	EnvDefaultOffset = "PAGINATION_DEFAULT_OFFSET"
+)
+
+// LoadDefaultsFromEnv sets package default limit, max limit and default offset from environment variables
+// EnvDefaultLimit, EnvMaxLimit and EnvDefaultOffset, unset variables leaving their default unchanged
+// Returns an error and changes no default if a value is not a non-negative integer
+func LoadDefaultsFromEnv() error {
+	limit, err := lookupEnvInt(EnvDefaultLimit, defaultLimit)
+	if err != nil {
+		return err
+	}
+
+	limitMax, err := lookupEnvInt(EnvMaxLimit, maxLimit)
+	if err != nil {
+		return err
+	}
+
+	offset, err := lookupEnvInt(EnvDefaultOffset, defaultOffset)
+	if err != nil {
+		return err
+	}
+
+	if offset > MaxOffset {
+		return fmt.Errorf("%s (%d) cannot exceed %d", EnvDefaultOffset, offset, MaxOffset)
+	}
+
+	defaultLimit, maxLimit, defaultOffset = limit, limitMax, offset
+	return nil
+}
+
+// lookupEnvInt returns the non-negative integer value of environment variable key, current if it is not set
+func lookupEnvInt(key string, current int) (int, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return current, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s (%q) is not an integer: %w", key, value, err)
+	}
+	if i < 0 {
+		return 0, fmt.Errorf("%s (%d) cannot be negative", key, i)
+	}
+	return i, nil
+}
@@ -0,0 +1,232 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreDefaults resets package defaults once the test is over
+func restoreDefaults(t *testing.T) {
+	limit, offset, limitMax, policy := CurrentDefaultLimit(), CurrentDefaultOffset(), CurrentMaxLimit(), CurrentZeroLimitPolicy()
+	t.Cleanup(func() {
+		defaultLimit, defaultOffset, maxLimit, zeroLimit = limit, offset, limitMax, policy
+	})
+}
+
+func TestDefaults(t *testing.T) {
+	t.Run("initial values are the package constants", func(t *testing.T) {
+		assert.Equal(t, DefaultLimit500, CurrentDefaultLimit())
+		assert.Equal(t, DefaultOffset, CurrentDefaultOffset())
+		assert.Equal(t, 0, CurrentMaxLimit())
+	})
+
+	t.Run("getters reflect set values", func(t *testing.T) {
+		restoreDefaults(t)
+
+		require.NoError(t, SetDefaultLimit(50))
+		require.NoError(t, SetDefaultOffset(10))
+
+		assert.Equal(t, 50, CurrentDefaultLimit())
+		assert.Equal(t, 10, CurrentDefaultOffset())
+		assert.Equal(t, Pagination{Offset: 10, Limit: 50}, Default())
+
+		page, err := GetFromRequest(httptest.NewRequest(http.MethodGet, "/", nil))
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 10, Limit: 50}, page)
+	})
+
+	t.Run("negative values are rejected", func(t *testing.T) {
+		restoreDefaults(t)
+
+		assert.Error(t, SetDefaultLimit(-1))
+		assert.Error(t, SetMaxLimit(-1))
+		assert.Error(t, SetDefaultOffset(-1))
+		if strconv.IntSize == 64 {
+			aboveMax := int64(MaxOffset) + 1
+			assert.Error(t, SetDefaultOffset(int(aboveMax)))
+		}
+
+		assert.Equal(t, DefaultLimit500, CurrentDefaultLimit())
+		assert.Equal(t, DefaultOffset, CurrentDefaultOffset())
+	})
+}
+
+func TestMaxLimit(t *testing.T) {
+	restoreDefaults(t)
+	require.NoError(t, SetMaxLimit(100))
+
+	for _, url := range []string{"/?limit=1000", "/?limit=0"} {
+		t.Run("GetFromRequest caps "+url, func(t *testing.T) {
+			page, err := GetFromRequest(httptest.NewRequest(http.MethodGet, url, nil))
+
+			require.NoError(t, err)
+			assert.Equal(t, Pagination{Offset: 0, Limit: 100}, page)
+		})
+
+		t.Run("Middleware caps "+url, func(t *testing.T) {
+			var page Pagination
+			api := gin.New()
+			api.Use(Middleware())
+			api.GET("/", func(c *gin.Context) {
+				page = MustGet(c)
+			})
+
+			api.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, url, nil))
+
+			assert.Equal(t, Pagination{Offset: 0, Limit: 100}, page)
+		})
+	}
+
+	t.Run("GetFromHeaders caps unbounded limit", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.Header.Set(HeaderLimit, "0")
+
+		page, err := GetFromHeaders(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: 100}, page)
+	})
+
+	t.Run("GetFromJSONBody caps unbounded limit", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"limit":0}`))
+
+		page, err := GetFromJSONBody(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: 100}, page)
+	})
+
+	t.Run("GetFromURLQueryWithKeys caps unbounded limit", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?size=0", nil)
+
+		page, err := GetFromURLQueryWithKeys(c, QueryKeys{Offset: "skip", Limit: "size"})
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: 100}, page)
+	})
+
+	t.Run("GetFromURLQueryAll caps limit", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?limit=1000", nil)
+
+		page, err := GetFromURLQueryAll(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: 100}, page)
+	})
+
+	t.Run("NewPagination caps limit", func(t *testing.T) {
+		page, err := NewPagination(0, 100000)
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: 100}, page)
+	})
+
+	t.Run("FromPageToken caps page size", func(t *testing.T) {
+		page, err := FromPageToken("", 1<<30)
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: 100}, page)
+	})
+}
+
+func TestZeroLimitPolicy(t *testing.T) {
+	t.Run("initial policy is unbounded", func(t *testing.T) {
+		assert.Equal(t, ZeroLimitUnbounded, CurrentZeroLimitPolicy())
+	})
+
+	t.Run("set policy applies to GetFromRequest", func(t *testing.T) {
+		restoreDefaults(t)
+		require.NoError(t, SetZeroLimitPolicy(ZeroLimitEmpty))
+
+		page, err := GetFromRequest(httptest.NewRequest(http.MethodGet, "/?limit=0", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: 0, EmptyPage: true}, page)
+	})
+
+	t.Run("set policy applies to GetFromURLQueryWithKeys", func(t *testing.T) {
+		restoreDefaults(t)
+		require.NoError(t, SetZeroLimitPolicy(ZeroLimitDefault))
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?size=0", nil)
+
+		page, err := GetFromURLQueryWithKeys(c, QueryKeys{Offset: "skip", Limit: "size"})
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: DefaultLimit500}, page)
+	})
+
+	t.Run("set policy applies to GetFromURLQueryAll", func(t *testing.T) {
+		restoreDefaults(t)
+		require.NoError(t, SetZeroLimitPolicy(ZeroLimitReject))
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?limit=0", nil)
+
+		_, err := GetFromURLQueryAll(c)
+
+		var valueErr BadRequestValueError
+		require.ErrorAs(t, err, &valueErr)
+		assert.Equal(t, "limit", valueErr.Key)
+	})
+
+	t.Run("unknown policy is rejected", func(t *testing.T) {
+		restoreDefaults(t)
+
+		assert.Error(t, SetZeroLimitPolicy(ZeroLimitPolicy(42)))
+		assert.Equal(t, ZeroLimitUnbounded, CurrentZeroLimitPolicy())
+	})
+}
+
+func TestLoadDefaultsFromEnv(t *testing.T) {
+	t.Run("set variables change defaults", func(t *testing.T) {
+		restoreDefaults(t)
+		t.Setenv(EnvDefaultLimit, "50")
+		t.Setenv(EnvMaxLimit, "100")
+		t.Setenv(EnvDefaultOffset, "10")
+
+		require.NoError(t, LoadDefaultsFromEnv())
+
+		assert.Equal(t, 50, CurrentDefaultLimit())
+		assert.Equal(t, 100, CurrentMaxLimit())
+		assert.Equal(t, 10, CurrentDefaultOffset())
+
+		page, err := GetFromRequest(httptest.NewRequest(http.MethodGet, "/?limit=1000", nil))
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 10, Limit: 100}, page)
+	})
+
+	t.Run("unset variables keep defaults", func(t *testing.T) {
+		restoreDefaults(t)
+		t.Setenv(EnvDefaultLimit, "50")
+
+		require.NoError(t, LoadDefaultsFromEnv())
+
+		assert.Equal(t, 50, CurrentDefaultLimit())
+		assert.Equal(t, 0, CurrentMaxLimit())
+		assert.Equal(t, DefaultOffset, CurrentDefaultOffset())
+	})
+
+	for name, value := range map[string]string{"garbage": "pouet", "negative": "-1", "empty": ""} {
+		t.Run(name+" value is rejected", func(t *testing.T) {
+			restoreDefaults(t)
+			t.Setenv(EnvDefaultLimit, "50")
+			t.Setenv(EnvMaxLimit, value)
+
+			assert.Error(t, LoadDefaultsFromEnv())
+
+			assert.Equal(t, DefaultLimit500, CurrentDefaultLimit())
+			assert.Equal(t, 0, CurrentMaxLimit())
+		})
+	}
+}
@@ -0,0 +1,13 @@
+//go:build echo
+
+package pagination
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// GetFromEchoContext gets page (limit and offset) from url query of an echo request
+// Only available when building with the "echo" build tag
+func GetFromEchoContext(c echo.Context) (Pagination, error) {
+	return GetFromRequest(c.Request())
+}
@@ -0,0 +1,58 @@
+//go:build echo
+
+package pagination
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFromEchoContext(t *testing.T) {
+	tests := map[string]struct {
+		offset  string
+		limit   string
+		want    Pagination
+		wantErr bool
+	}{
+		"when offset cannot be converted to int, returns error": {
+			offset:  "pouet",
+			limit:   "100",
+			wantErr: true,
+		},
+		"when limit is negative, returns error": {
+			offset:  "100",
+			limit:   "-2",
+			wantErr: true,
+		},
+		"nominal": {
+			offset: "10",
+			limit:  "100",
+			want: Pagination{
+				Offset: 10,
+				Limit:  100,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			url := fmt.Sprintf("/?offset=%s&limit=%s", tt.offset, tt.limit)
+			r := httptest.NewRequest(http.MethodGet, url, nil)
+			rw := httptest.NewRecorder()
+
+			page, err := GetFromEchoContext(e.NewContext(r, rw))
+
+			assert.Equal(t, tt.want, page)
+			if tt.wantErr {
+				assert.ErrorAs(t, err, &BadRequestValueError{})
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
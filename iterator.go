@@ -0,0 +1,177 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PageableIter lazily decodes data elements of a JSON encoded Pageable, one at a time, data being read under DataFieldName
+// Limit, Offset and Total are set once read, at top level or in a meta object (see FormatMeta),
+// i.e. before the first element if they precede data in the JSON object
+type PageableIter[T any] struct {
+	Limit  int
+	Offset int
+	Total  int64
+
+	decoder *json.Decoder
+	started bool
+	done    bool
+	err     error
+}
+
+// IteratePageable returns an iterator over data elements of the JSON encoded Pageable read from r
+func IteratePageable[T any](r io.Reader) *PageableIter[T] {
+	return &PageableIter[T]{decoder: json.NewDecoder(r)}
+}
+
+// Next decodes the next data element, returning false once all elements are read
+// Errors are sticky: once an error is returned, subsequent calls return it again
+func (it *PageableIter[T]) Next() (T, bool, error) {
+	var value T
+	if it.err != nil {
+		return value, false, it.err
+	}
+	if it.done {
+		return value, false, nil
+	}
+
+	if !it.started {
+		it.started = true
+		if err := it.readUntilData(); err != nil {
+			return value, false, it.fail(err)
+		}
+		if it.done {
+			return value, false, nil
+		}
+	}
+
+	if !it.decoder.More() {
+		if err := it.readAfterData(); err != nil {
+			return value, false, it.fail(err)
+		}
+		it.done = true
+		return value, false, nil
+	}
+
+	if err := it.decoder.Decode(&value); err != nil {
+		return value, false, it.fail(fmt.Errorf("unable to decode JSON element for %T datatype: %w", value, err))
+	}
+	return value, true, nil
+}
+
+// fail stores err so that it is returned by subsequent calls to Next
+func (it *PageableIter[T]) fail(err error) error {
+	it.err = err
+	return err
+}
+
+// readUntilData reads the JSON object until the opening of data array
+func (it *PageableIter[T]) readUntilData() error {
+	if err := it.expectDelim('{'); err != nil {
+		return err
+	}
+
+	for it.decoder.More() {
+		key, err := it.readKey()
+		if err != nil {
+			return err
+		}
+
+		if key != DataFieldName {
+			if err := it.readField(key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		token, err := it.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return it.readRemainingFields()
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return errors.New("unable to iterate data field of pageable, it is not an array")
+		}
+		return nil
+	}
+
+	it.done = true
+	return it.expectDelim('}')
+}
+
+// readAfterData reads the end of data array and the remaining fields of the JSON object
+func (it *PageableIter[T]) readAfterData() error {
+	if err := it.expectDelim(']'); err != nil {
+		return err
+	}
+	return it.readRemainingFields()
+}
+
+// readRemainingFields reads the fields following data until the end of the JSON object
+func (it *PageableIter[T]) readRemainingFields() error {
+	it.done = true
+	for it.decoder.More() {
+		key, err := it.readKey()
+		if err != nil {
+			return err
+		}
+		if err := it.readField(key); err != nil {
+			return err
+		}
+	}
+	return it.expectDelim('}')
+}
+
+// readKey reads the key of the next field of the JSON object
+func (it *PageableIter[T]) readKey() (string, error) {
+	token, err := it.decoder.Token()
+	if err != nil {
+		return "", err
+	}
+	key, ok := token.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected JSON token %v", token)
+	}
+	return key, nil
+}
+
+// readField decodes the value of a pagination field or meta object, skipping unknown fields
+func (it *PageableIter[T]) readField(key string) error {
+	switch key {
+	case "meta":
+		var meta pageableMeta
+		if err := it.decoder.Decode(&meta); err != nil {
+			return err
+		}
+		it.Limit, it.Offset = meta.Limit, meta.Offset
+		if meta.Total != nil {
+			it.Total = *meta.Total
+		}
+		return nil
+	case "limit":
+		return it.decoder.Decode(&it.Limit)
+	case "offset":
+		return it.decoder.Decode(&it.Offset)
+	case "total":
+		return it.decoder.Decode(&it.Total)
+	default:
+		var skipped json.RawMessage
+		return it.decoder.Decode(&skipped)
+	}
+}
+
+// expectDelim reads the next token, returning an error if it is not delim
+func (it *PageableIter[T]) expectDelim(delim json.Delim) error {
+	token, err := it.decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("unexpected JSON token %v, expected %v", token, delim)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIteratePageable(t *testing.T) {
+	t.Run("iterates all elements and reads metadata", func(t *testing.T) {
+		it := IteratePageable[Label](strings.NewReader(
+			`{"limit":2,"offset":10,"data":[{"label":"first"},{"label":"second"}],"total":12}`))
+
+		var labels []string
+		for {
+			label, ok, err := it.Next()
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			labels = append(labels, label.Label.String)
+		}
+
+		assert.Equal(t, []string{"first", "second"}, labels)
+		assert.Equal(t, 2, it.Limit)
+		assert.Equal(t, 10, it.Offset)
+		assert.Equal(t, int64(12), it.Total)
+	})
+
+	t.Run("reads pageable marshalled in meta format under DataFieldName", func(t *testing.T) {
+		t.Cleanup(func() { ResponseFormat, DataFieldName = FormatFlat, "data" })
+		ResponseFormat, DataFieldName = FormatMeta, "items"
+		body, err := json.Marshal(MockPageableLabel("first", "second"))
+		require.NoError(t, err)
+
+		it := IteratePageable[Label](bytes.NewReader(body))
+
+		var labels []string
+		for {
+			label, ok, err := it.Next()
+			require.NoError(t, err)
+			if !ok {
+				break
+			}
+			labels = append(labels, label.Label.String)
+		}
+
+		assert.Equal(t, []string{"first", "second"}, labels)
+		assert.Equal(t, 999999, it.Limit)
+		assert.Equal(t, 0, it.Offset)
+		assert.Equal(t, int64(2), it.Total)
+	})
+
+	t.Run("partial consumption only decodes requested elements", func(t *testing.T) {
+		it := IteratePageable[Label](strings.NewReader(
+			`{"limit":3,"offset":0,"total":3,"data":[{"label":"first"},"malformed",{"label":"third"}]}`))
+
+		label, ok, err := it.Next()
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, "first", label.Label.String)
+		assert.Equal(t, int64(3), it.Total)
+	})
+
+	t.Run("malformed element returns sticky error", func(t *testing.T) {
+		it := IteratePageable[Label](strings.NewReader(`{"data":[{"label":"first"},1,{"label":"third"}]}`))
+
+		_, ok, err := it.Next()
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		_, ok, err = it.Next()
+		assert.Error(t, err)
+		assert.False(t, ok)
+
+		_, ok, err = it.Next()
+		assert.Error(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("null or empty data has no elements", func(t *testing.T) {
+		for _, input := range []string{`{"total":0,"data":null}`, `{"total":0,"data":[]}`, `{"total":0}`} {
+			it := IteratePageable[Label](strings.NewReader(input))
+
+			_, ok, err := it.Next()
+
+			assert.NoError(t, err, input)
+			assert.False(t, ok, input)
+		}
+	})
+
+	t.Run("data which is not an array returns error", func(t *testing.T) {
+		it := IteratePageable[Label](strings.NewReader(`{"data":{"label":"first"}}`))
+
+		_, _, err := it.Next()
+
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed JSON returns error", func(t *testing.T) {
+		it := IteratePageable[Label](strings.NewReader(`["data"]`))
+
+		_, _, err := it.Next()
+
+		assert.Error(t, err)
+	})
+}
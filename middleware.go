@@ -0,0 +1,84 @@
+package pagination
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinContextKey is the key under which Middleware stores pagination in gin context
+const GinContextKey = "pagination"
+
+// middlewareConfig holds the configuration of Middleware
+type middlewareConfig struct {
+	defaultLimit int
+	maxLimit     int
+	zeroLimit    ZeroLimitPolicy
+}
+
+// MiddlewareOption configures Middleware
+type MiddlewareOption func(*middlewareConfig)
+
+// WithDefaultLimit sets the limit used by Middleware when none is requested
+func WithDefaultLimit(limit int) MiddlewareOption {
+	return func(config *middlewareConfig) {
+		config.defaultLimit = limit
+	}
+}
+
+// WithMaxLimit sets the highest limit allowed by Middleware, higher requested limits, unbounded ones included, being lowered to it
+// It defaults to the package max limit, see SetMaxLimit
+func WithMaxLimit(limit int) MiddlewareOption {
+	return func(config *middlewareConfig) {
+		config.maxLimit = limit
+	}
+}
+
+// WithZeroLimitPolicy sets how Middleware handles an explicit limit=0
+// It defaults to the package policy, see SetZeroLimitPolicy
+func WithZeroLimitPolicy(policy ZeroLimitPolicy) MiddlewareOption {
+	return func(config *middlewareConfig) {
+		config.zeroLimit = policy
+	}
+}
+
+// Middleware parses pagination from url query and stores it in gin context, see Get and MustGet
+// Aborts with 400 and a ResponseError body if pagination cannot be parsed
+func Middleware(opts ...MiddlewareOption) gin.HandlerFunc {
+	config := middlewareConfig{defaultLimit: CurrentDefaultLimit(), maxLimit: CurrentMaxLimit(), zeroLimit: CurrentZeroLimitPolicy()}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return func(c *gin.Context) {
+		opts := DefaultOptions()
+		opts.DefaultLimit, opts.MaxLimit, opts.ZeroLimit = config.defaultLimit, config.maxLimit, config.zeroLimit
+		page, err := getFromQueryWithOptions(c.Request.URL.Query(), opts)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, NewResponseError(err))
+			return
+		}
+
+		c.Set(GinContextKey, page)
+		c.Next()
+	}
+}
+
+// Get returns pagination stored in gin context by Middleware
+func Get(c *gin.Context) (Pagination, bool) {
+	value, ok := c.Get(GinContextKey)
+	if !ok {
+		return Pagination{}, false
+	}
+	page, ok := value.(Pagination)
+	return page, ok
+}
+
+// MustGet returns pagination stored in gin context by Middleware, panics if there is none
+func MustGet(c *gin.Context) Pagination {
+	page, ok := Get(c)
+	if !ok {
+		panic("pagination: no pagination in gin context, is Middleware registered?")
+	}
+	return page
+}
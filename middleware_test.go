@@ -0,0 +1,102 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := map[string]struct {
+		opts       []MiddlewareOption
+		url        string
+		want       Pagination
+		wantCode   int
+		wantErrKey string
+	}{
+		"when pagination is absent, stores defaults": {
+			url:      "/",
+			want:     Pagination{Offset: DefaultOffset, Limit: DefaultLimit500},
+			wantCode: http.StatusOK,
+		},
+		"when default limit is configured, stores it": {
+			opts:     []MiddlewareOption{WithDefaultLimit(20)},
+			url:      "/",
+			want:     Pagination{Offset: DefaultOffset, Limit: 20},
+			wantCode: http.StatusOK,
+		},
+		"when limit is above configured max, stores max": {
+			opts:     []MiddlewareOption{WithMaxLimit(50)},
+			url:      "/?limit=100",
+			want:     Pagination{Offset: 0, Limit: 50},
+			wantCode: http.StatusOK,
+		},
+		"when limit is unbounded with configured max, stores max": {
+			opts:     []MiddlewareOption{WithMaxLimit(50)},
+			url:      "/?limit=0",
+			want:     Pagination{Offset: 0, Limit: 50},
+			wantCode: http.StatusOK,
+		},
+		"when zero limit policy is configured, applies it": {
+			opts:     []MiddlewareOption{WithDefaultLimit(20), WithZeroLimitPolicy(ZeroLimitDefault)},
+			url:      "/?limit=0",
+			want:     Pagination{Offset: 0, Limit: 20},
+			wantCode: http.StatusOK,
+		},
+		"when zero limit policy rejects limit=0, aborts with 400": {
+			opts:       []MiddlewareOption{WithZeroLimitPolicy(ZeroLimitReject)},
+			url:        "/?limit=0",
+			wantCode:   http.StatusBadRequest,
+			wantErrKey: "limit",
+		},
+		"nominal": {
+			opts:     []MiddlewareOption{WithDefaultLimit(20), WithMaxLimit(50)},
+			url:      "/?offset=10&limit=30",
+			want:     Pagination{Offset: 10, Limit: 30},
+			wantCode: http.StatusOK,
+		},
+		"when pagination is invalid, aborts with 400": {
+			url:        "/?offset=-1",
+			wantCode:   http.StatusBadRequest,
+			wantErrKey: "offset",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var page Pagination
+			api := gin.New()
+			api.Use(Middleware(tt.opts...))
+			api.GET("/", func(c *gin.Context) {
+				page = MustGet(c)
+			})
+
+			rw := httptest.NewRecorder()
+			api.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, tt.url, nil))
+
+			assert.Equal(t, tt.wantCode, rw.Code)
+			assert.Equal(t, tt.want, page)
+			if tt.wantCode == http.StatusBadRequest {
+				var body ResponseError
+				require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &body))
+				assert.Contains(t, body.Message, tt.wantErrKey)
+				assert.Equal(t, CodeBadRequestValue, body.Code)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	t.Run("when middleware is not registered, returns false and MustGet panics", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+		_, found := Get(c)
+
+		assert.False(t, found)
+		assert.Panics(t, func() { MustGet(c) })
+	})
+}
@@ -2,17 +2,25 @@ package pagination
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // Pagination to use this struct for all endpoint in the project that require paging
+// A Limit of 0 means unbounded: all rows starting at Offset are returned, unless EmptyPage is set
+// EmptyPage marks a pagination returning no rows, with a Limit of 0, see ZeroLimitEmpty. It is only marshalled when set
 type Pagination struct {
-	Offset int
-	Limit  int
+	Offset    int  `json:"offset"`
+	Limit     int  `json:"limit"`
+	EmptyPage bool `json:"empty_page,omitempty"`
 }
 
 // Pageable describes a generic model
@@ -23,9 +31,54 @@ type Pageable struct {
 	Data   interface{} `json:"data"`
 }
 
+// PageableOf describes a generic model with typed data, marshalled the same way as Pageable
+type PageableOf[T any] struct {
+	Limit  int   `json:"limit"`
+	Offset int   `json:"offset"`
+	Total  int64 `json:"total"`
+	Data   []T   `json:"data"`
+}
+
+// PageableEstimate describes a generic model whose total may be an estimate, e.g. from EXPLAIN row estimates on huge tables
+type PageableEstimate struct {
+	Limit          int         `json:"limit"`
+	Offset         int         `json:"offset"`
+	EstimatedTotal int64       `json:"estimated_total"`
+	TotalIsExact   bool        `json:"total_is_exact"`
+	Data           interface{} `json:"data"`
+}
+
+// PageableLinks holds navigation links of a page, links which do not apply (e.g. Prev on first page) being nil and omitted
+type PageableLinks struct {
+	Self  *string `json:"self,omitempty"`
+	First *string `json:"first,omitempty"`
+	Prev  *string `json:"prev,omitempty"`
+	Next  *string `json:"next,omitempty"`
+	Last  *string `json:"last,omitempty"`
+}
+
+// PageableWithLinks describes a generic model with navigation links in its body, see BuildPageableWithLinks
+type PageableWithLinks struct {
+	Limit  int           `json:"limit"`
+	Offset int           `json:"offset"`
+	Total  int64         `json:"total"`
+	Data   interface{}   `json:"data"`
+	Links  PageableLinks `json:"links"`
+}
+
+// SlimPageable describes a generic model omitting zero valued limit, offset and total when marshalled
+type SlimPageable struct {
+	Limit  int         `json:"limit,omitempty"`
+	Offset int         `json:"offset,omitempty"`
+	Total  int64       `json:"total,omitempty"`
+	Data   interface{} `json:"data"`
+}
+
 // ResponseError encapsulates error in message to send to HTTP client
+// Code is a machine-readable error code, see NewResponseError
 type ResponseError struct {
 	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
 }
 
 // NotFoundError is returned by repository when an entity is not found by its ID
@@ -76,6 +129,20 @@ func (e RowsAffectedError) Error() string {
 		e.ExpectedRows)
 }
 
+// CheckRowsAffected returns a RowsAffectedError if affected, as returned by sql.Result.RowsAffected, is not expected
+// Counts are compared as int64 and converted to int in the error
+func CheckRowsAffected(usecase string, versionID uint64, affected, expected int64) error {
+	if affected == expected {
+		return nil
+	}
+	return RowsAffectedError{
+		Usecase:      usecase,
+		VersionID:    versionID,
+		AffectedRows: int(affected),
+		ExpectedRows: int(expected),
+	}
+}
+
 // BadRequestKeyError defines errors for bad requests when Key is not found in url
 type BadRequestKeyError struct {
 	Key string
@@ -111,6 +178,48 @@ func (e MissingQueryParameterError) Error() string {
 	return fmt.Sprintf("missing key %q in query string", e.Key)
 }
 
+// LimitTooLargeError defines errors when the requested limit is higher than the max limit allowed
+// A Requested limit of 0 is an unbounded one
+type LimitTooLargeError struct {
+	Requested int
+	Max       int
+}
+
+func (e LimitTooLargeError) Error() string {
+	if e.Requested == 0 {
+		return fmt.Sprintf("bad request: unbounded limit exceeds max limit (%d)", e.Max)
+	}
+	return fmt.Sprintf("bad request: limit (%d) exceeds max limit (%d)", e.Requested, e.Max)
+}
+
+// OffsetBeyondTotalError defines errors when the requested offset is past the last element
+type OffsetBeyondTotalError struct {
+	Offset int
+	Total  int64
+}
+
+func (e OffsetBeyondTotalError) Error() string {
+	return fmt.Sprintf("offset (%d) is beyond total (%d)", e.Offset, e.Total)
+}
+
+// MultiError collects several errors, e.g. one BadRequestValueError per invalid query parameter
+type MultiError struct {
+	Errors []error
+}
+
+func (e MultiError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns collected errors, so that errors.Is and errors.As match any of them
+func (e MultiError) Unwrap() []error {
+	return e.Errors
+}
+
 // Label describes label entity
 type Label struct {
 	Label NullEmptyString `json:"label"`
@@ -122,12 +231,25 @@ type LabelRow struct {
 	Label NullEmptyString
 }
 
+// Nullable is implemented by all null types of the package
+type Nullable interface {
+	IsValid() bool
+	IsEmpty() bool
+}
+
 // NullBool encapsulates sql null boolean with custom marshalling/unmarshalling
 type NullBool struct {
 	sql.NullBool
 }
 
+// IsValid returns true if models.NullBool is valid
+func (nb NullBool) IsValid() bool {
+	return nb.Valid
+}
+
 // IsEmpty returns true if models.NullBool is either not valid or false
+// Unlike MapToBool, which gives a pointer to false for a valid false, it treats a valid false as "no value", e.g. for
+// MapForRequest. Use IsValid, MapToBool or ValueOr to tell a valid false apart from an invalid NullBool
 func (nb NullBool) IsEmpty() bool {
 	if !nb.Valid || !nb.Bool {
 		return true
@@ -135,7 +257,8 @@ func (nb NullBool) IsEmpty() bool {
 	return false
 }
 
-// MarshalJSON marshals models.NullBool datatype
+// MarshalJSON marshals models.NullBool datatype as a tri-state: true, false, or null when not valid
+// Unlike IsEmpty, a valid false is not collapsed into null
 func (nb NullBool) MarshalJSON() ([]byte, error) {
 	if !nb.Valid {
 		return []byte("null"), nil
@@ -154,6 +277,41 @@ func (nb *NullBool) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// Scan scans bool, integer (0 is false, any other value is true) and string ("0", "1", "true", "false")
+// values to models.NullBool datatype
+func (nb *NullBool) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*nb = NullBool{}
+		return nil
+	case bool:
+		nb.Bool, nb.Valid = v, true
+		return nil
+	case int64:
+		nb.Bool, nb.Valid = v != 0, true
+		return nil
+	case []byte:
+		return nb.scanString(string(v))
+	case string:
+		return nb.scanString(v)
+	default:
+		return fmt.Errorf("could not scan %T into NullBool", value)
+	}
+}
+
+// scanString scans string representation of a boolean to models.NullBool datatype
+func (nb *NullBool) scanString(value string) error {
+	switch value {
+	case "1", "true":
+		nb.Bool, nb.Valid = true, true
+	case "0", "false":
+		nb.Bool, nb.Valid = false, true
+	default:
+		return fmt.Errorf("could not scan %q into NullBool", value)
+	}
+	return nil
+}
+
 // MapToBool returns NullBool boolean value pointer if valid, nil otherwise
 func (nb NullBool) MapToBool() *bool {
 	if !nb.Valid {
@@ -163,6 +321,34 @@ func (nb NullBool) MapToBool() *bool {
 	return &value
 }
 
+// MapToBoolStrict returns NullBool boolean value pointer if valid, nil only when invalid, a valid false giving a pointer to false
+// Unlike IsEmpty, it never collapses a valid false into "no value". It gives the same result as MapToBool,
+// its name making that strict handling explicit at call sites, e.g. for PATCH payloads where false is meaningful
+func (nb NullBool) MapToBoolStrict() *bool {
+	return nb.MapToBool()
+}
+
+// ValueOr returns NullBool boolean value if valid, def otherwise
+func (nb NullBool) ValueOr(def bool) bool {
+	if !nb.Valid {
+		return def
+	}
+	return nb.Bool
+}
+
+// MapToString returns a pointer to trueStr or falseStr if NullBool is valid, nil otherwise
+func (nb NullBool) MapToString(trueStr, falseStr string) *string {
+	if !nb.Valid {
+		return nil
+	}
+
+	value := falseStr
+	if nb.Bool {
+		value = trueStr
+	}
+	return &value
+}
+
 // MapForRequest returns boolean value (integer) if valid, nil otherwise
 func (nb NullBool) MapForRequest() interface{} {
 	if !nb.Valid {
@@ -176,11 +362,19 @@ func (nb NullBool) MapForRequest() interface{} {
 }
 
 // NullInt encapsulates sql null int with custom marshalling/unmarshalling
+// Scan and Value keep a valid 0, so that it reads back unchanged from the database. MarshalJSON, MapForRequest and IsEmpty
+// treat a valid 0 as empty, marshalling it as null as in previous versions: use MapForRequestZero, or JSONNullInt64
+// for JSON, where 0 must be kept
 type NullInt struct {
 	sql.NullInt64
 }
 
-// MarshalJSON marshals models.NullInt datatype
+// IsValid returns true if models.NullInt is valid
+func (ni NullInt) IsValid() bool {
+	return ni.Valid
+}
+
+// MarshalJSON marshals models.NullInt datatype, a valid 0 being marshalled as null
 func (ni NullInt) MarshalJSON() ([]byte, error) {
 	if !ni.Valid || ni.Int64 == 0 {
 		return []byte("null"), nil
@@ -201,6 +395,7 @@ func (ni *NullInt) UnmarshalJSON(b []byte) error {
 }
 
 // Scan scans int to models.NullInt datatype
+// A zero value is scanned as valid, so that a value written with Value reads back unchanged
 func (ni *NullInt) Scan(value interface{}) error {
 	if reflect.TypeOf(value) == nil {
 		*ni = NullInt{}
@@ -211,15 +406,18 @@ func (ni *NullInt) Scan(value interface{}) error {
 		return err
 	}
 
-	ni.Int64 = i.Int64
-	if i.Int64 == 0 {
-		ni.Valid = false
-	} else {
-		ni.Valid = true
-	}
+	ni.NullInt64 = i
 	return nil
 }
 
+// Value implements the driver.Valuer interface, writing the stored value even when zero
+func (ni NullInt) Value() (driver.Value, error) {
+	if !ni.Valid {
+		return nil, nil
+	}
+	return ni.Int64, nil
+}
+
 // MapToInt64 returns NullInt integer value pointer if valid, nil otherwise
 func (ni NullInt) MapToInt64() *int64 {
 	if !ni.Valid {
@@ -243,12 +441,244 @@ func (ni NullInt) MapToInt32() (*int32, error) {
 	return &value, nil
 }
 
+// MapToInt returns NullInt integer value pointer if valid and in int range (platform dependent), nil otherwise
+func (ni NullInt) MapToInt() (*int, error) {
+	if !ni.Valid {
+		return nil, nil
+	}
+
+	if ni.Int64 > math.MaxInt || ni.Int64 < math.MinInt {
+		return nil, fmt.Errorf("could not convert NullInt to *int, value %d out of range", ni.Int64)
+	}
+
+	value := int(ni.Int64)
+	return &value, nil
+}
+
+// Add returns NullInt incremented by delta, an invalid NullInt being treated as 0
+// The result is always valid, even when it is 0
+func (ni NullInt) Add(delta int64) NullInt {
+	return NullInt{sql.NullInt64{Int64: ni.ValueOr(0) + delta, Valid: true}}
+}
+
+// ValueOr returns NullInt integer value if valid, def otherwise
+func (ni NullInt) ValueOr(def int64) int64 {
+	if !ni.Valid {
+		return def
+	}
+	return ni.Int64
+}
+
+// HasFlag returns true if NullInt is valid and all bits of flag are set
+func (ni NullInt) HasFlag(flag int64) bool {
+	return ni.Valid && ni.Int64&flag == flag
+}
+
+// SetFlag returns NullInt with bits of flag set, an invalid NullInt being treated as holding no flag
+func (ni NullInt) SetFlag(flag int64) NullInt {
+	return NullInt{sql.NullInt64{Int64: ni.ValueOr(0) | flag, Valid: true}}
+}
+
+// ClearFlag returns NullInt with bits of flag cleared, an invalid NullInt being returned as is
+// Clearing all flags gives a valid zero, not an invalid NullInt
+func (ni NullInt) ClearFlag(flag int64) NullInt {
+	if !ni.Valid {
+		return ni
+	}
+	return NullInt{sql.NullInt64{Int64: ni.Int64 &^ flag, Valid: true}}
+}
+
+// MapForRequest returns NullInt integer value if valid and not zero, nil otherwise
+func (ni NullInt) MapForRequest() interface{} {
+	if ni.IsEmpty() {
+		return nil
+	}
+	return ni.Int64
+}
+
+// MapForRequestZero returns NullInt integer value if valid, including zero, nil otherwise
+func (ni NullInt) MapForRequestZero() interface{} {
+	if !ni.Valid {
+		return nil
+	}
+	return ni.Int64
+}
+
+// MapNullInts returns the integer values of valid NullInt of ns, e.g. to build an IN clause, invalid ones being dropped
+func MapNullInts(ns []NullInt) []int64 {
+	values := make([]int64, 0, len(ns))
+	for _, ni := range ns {
+		if ni.Valid {
+			values = append(values, ni.Int64)
+		}
+	}
+	return values
+}
+
+// NullInt32 encapsulates sql null int32 with custom marshalling/unmarshalling, zero being a valid value
+type NullInt32 struct {
+	sql.NullInt32
+}
+
+// IsValid returns true if models.NullInt32 is valid
+func (ni NullInt32) IsValid() bool {
+	return ni.Valid
+}
+
+// IsEmpty returns true if models.NullInt32 is not valid, zero being a meaningful value
+func (ni NullInt32) IsEmpty() bool {
+	return !ni.Valid
+}
+
+// MarshalJSON marshals models.NullInt32 datatype
+func (ni NullInt32) MarshalJSON() ([]byte, error) {
+	if !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int32)
+}
+
+// UnmarshalJSON unmarshals models.NullInt32 datatype
+func (ni *NullInt32) UnmarshalJSON(b []byte) error {
+	var x *int32
+	if err := json.Unmarshal(b, &x); err != nil {
+		return err
+	}
+	if x == nil {
+		*ni = NullInt32{}
+		return nil
+	}
+	ni.Int32, ni.Valid = *x, true
+	return nil
+}
+
+// Scan scans integers to models.NullInt32 datatype
+// Returns an error if the value does not fit in an int32
+func (ni *NullInt32) Scan(value interface{}) error {
+	var i sql.NullInt64
+	if err := i.Scan(value); err != nil {
+		return err
+	}
+
+	if !i.Valid {
+		*ni = NullInt32{}
+		return nil
+	}
+
+	if i.Int64 > math.MaxInt32 || i.Int64 < math.MinInt32 {
+		return fmt.Errorf("could not scan %d into NullInt32, value out of range", i.Int64)
+	}
+
+	ni.Int32, ni.Valid = int32(i.Int64), true
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (ni NullInt32) Value() (driver.Value, error) {
+	if !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int32), nil
+}
+
+// MapToInt32 returns NullInt32 integer value pointer if valid, nil otherwise
+func (ni NullInt32) MapToInt32() *int32 {
+	if !ni.Valid {
+		return nil
+	}
+	value := ni.Int32
+	return &value
+}
+
+// NullInt16 encapsulates sql null int16 with custom marshalling/unmarshalling, zero being a valid value
+type NullInt16 struct {
+	sql.NullInt16
+}
+
+// IsValid returns true if models.NullInt16 is valid
+func (ni NullInt16) IsValid() bool {
+	return ni.Valid
+}
+
+// IsEmpty returns true if models.NullInt16 is not valid, zero being a meaningful value
+func (ni NullInt16) IsEmpty() bool {
+	return !ni.Valid
+}
+
+// MarshalJSON marshals models.NullInt16 datatype
+func (ni NullInt16) MarshalJSON() ([]byte, error) {
+	if !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int16)
+}
+
+// UnmarshalJSON unmarshals models.NullInt16 datatype
+func (ni *NullInt16) UnmarshalJSON(b []byte) error {
+	var x *int16
+	if err := json.Unmarshal(b, &x); err != nil {
+		return err
+	}
+	if x == nil {
+		*ni = NullInt16{}
+		return nil
+	}
+	ni.Int16, ni.Valid = *x, true
+	return nil
+}
+
+// Scan scans integers to models.NullInt16 datatype
+// Returns an error if the value does not fit in an int16
+func (ni *NullInt16) Scan(value interface{}) error {
+	var i sql.NullInt64
+	if err := i.Scan(value); err != nil {
+		return err
+	}
+
+	if !i.Valid {
+		*ni = NullInt16{}
+		return nil
+	}
+
+	if i.Int64 > math.MaxInt16 || i.Int64 < math.MinInt16 {
+		return fmt.Errorf("could not scan %d into NullInt16, value out of range", i.Int64)
+	}
+
+	ni.Int16, ni.Valid = int16(i.Int64), true
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (ni NullInt16) Value() (driver.Value, error) {
+	if !ni.Valid {
+		return nil, nil
+	}
+	return int64(ni.Int16), nil
+}
+
+// MapToInt16 returns NullInt16 integer value pointer if valid, nil otherwise
+func (ni NullInt16) MapToInt16() *int16 {
+	if !ni.Valid {
+		return nil
+	}
+	value := ni.Int16
+	return &value
+}
+
 // NullFloat encapsulates sql null float with custom marshalling/unmarshalling
+// Scan and Value keep a valid 0, so that it reads back unchanged from the database. MarshalJSON, MapForRequest and IsEmpty
+// treat a valid 0 as empty, marshalling it as null as in previous versions: use MapForRequestZero, or JSONNullFloat64
+// for JSON, where 0 must be kept
 type NullFloat struct {
 	sql.NullFloat64
 }
 
-// MarshalJSON marshals models.NullFloat datatype
+// IsValid returns true if models.NullFloat is valid
+func (nf NullFloat) IsValid() bool {
+	return nf.Valid
+}
+
+// MarshalJSON marshals models.NullFloat datatype, a valid 0 being marshalled as null
 func (nf NullFloat) MarshalJSON() ([]byte, error) {
 	if !nf.Valid || nf.Float64 == 0.0 {
 		return []byte("null"), nil
@@ -266,15 +696,61 @@ func (nf *NullFloat) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// NullFloatScale is the number of decimal places kept by NullFloat.Scan, digits beyond being rounded with NullFloatRounding
+var NullFloatScale = 4
+
+// RoundingMode defines how NullFloat.Scan drops digits beyond NullFloatScale
+type RoundingMode int
+
+const (
+	// RoundDown truncates toward zero, e.g. 1.23459 gives 1.2345 at scale 4
+	RoundDown RoundingMode = iota
+	// RoundHalfUp rounds to nearest, halves away from zero, e.g. 1.23455 gives 1.2346 at scale 4
+	RoundHalfUp
+	// RoundHalfEven rounds to nearest, halves to the even digit, e.g. 1.23465 gives 1.2346 at scale 4
+	RoundHalfEven
+)
+
+// NullFloatRounding is the rounding mode used by NullFloat.Scan
+// It defaults to RoundDown so that scanned values are unchanged from previous versions, use RoundHalfEven for unbiased rounding
+// It is meant to be configured once at startup and is not safe for concurrent modification
+var NullFloatRounding = RoundDown
+
+// round rounds value to scale decimal places using mode
+func (mode RoundingMode) round(value float64, scale int) float64 {
+	scaled := value * math.Pow10(scale)
+	switch mode {
+	case RoundHalfUp:
+		scaled = math.Round(scaled)
+	case RoundHalfEven:
+		scaled = math.RoundToEven(scaled)
+	default:
+		scaled = math.Trunc(scaled)
+	}
+	return scaled / math.Pow10(scale)
+}
+
 // Scan scans numbers to NullFloat datatype
+// String and byte slice values, as returned by some drivers for NUMERIC columns, are parsed with strconv.ParseFloat
+// Values are rounded to NullFloatScale decimal places with NullFloatRounding, a zero value being scanned as valid
 func (nf *NullFloat) Scan(value interface{}) error {
 	if reflect.TypeOf(value) == nil {
 		*nf = NullFloat{}
 		return nil
 	}
 
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+
 	var i sql.NullFloat64
-	if err := i.Scan(value); err != nil {
+	if v, ok := value.(string); ok {
+		f, err := parseNullFloat(v)
+		if err != nil {
+			return err
+		}
+		i.Float64 = f
+	} else if err := i.Scan(value); err != nil {
 		return err
 	}
 
@@ -284,16 +760,28 @@ func (nf *NullFloat) Scan(value interface{}) error {
 		return nil
 	}
 
-	i.Float64 = float64(int(i.Float64*math.Pow10(4))) / math.Pow10(4)
-	nf.Float64 = i.Float64
-	if i.Float64 == 0 {
-		nf.Valid = false
-	} else {
-		nf.Valid = true
-	}
+	i.Float64 = NullFloatRounding.round(i.Float64, NullFloatScale)
+	nf.Float64, nf.Valid = i.Float64, true
 	return nil
 }
 
+// Value implements the driver.Valuer interface, writing the stored value even when zero
+func (nf NullFloat) Value() (driver.Value, error) {
+	if !nf.Valid {
+		return nil, nil
+	}
+	return nf.Float64, nil
+}
+
+// parseNullFloat parses string representation of a number for NullFloat.Scan
+func parseNullFloat(value string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not scan %q into NullFloat: %w", value, err)
+	}
+	return f, nil
+}
+
 // IsEmpty returns true if models.NullFloat is either not valid or empty
 func (nf NullFloat) IsEmpty() bool {
 	if !nf.Valid || nf.Float64 == 0 {
@@ -333,12 +821,52 @@ func (nf NullFloat) MapToFloat32() (*float32, error) {
 	return &value, nil
 }
 
+// GreaterThan returns true if NullFloat is valid and greater than f, an invalid NullFloat never being greater
+func (nf NullFloat) GreaterThan(f float64) bool {
+	return nf.Valid && nf.Float64 > f
+}
+
+// LessThan returns true if NullFloat is valid and less than f, an invalid NullFloat never being less
+func (nf NullFloat) LessThan(f float64) bool {
+	return nf.Valid && nf.Float64 < f
+}
+
+// EqualTo returns true if NullFloat is valid and equal to f once f is rounded as Scan does,
+// i.e. to NullFloatScale decimal places with NullFloatRounding. An invalid NullFloat is never equal
+func (nf NullFloat) EqualTo(f float64) bool {
+	return nf.Valid && nf.Float64 == NullFloatRounding.round(f, NullFloatScale)
+}
+
+// MapForRequest returns NullFloat value if valid and not zero, nil otherwise
+func (nf NullFloat) MapForRequest() interface{} {
+	if nf.IsEmpty() {
+		return nil
+	}
+	return nf.Float64
+}
+
+// MapForRequestZero returns NullFloat value if valid, including zero, nil otherwise
+func (nf NullFloat) MapForRequestZero() interface{} {
+	if !nf.Valid {
+		return nil
+	}
+	return nf.Float64
+}
+
 // NullString encapsulates sql null string with custom marshalling/unmarshalling
+// Scan and Value keep a valid empty string, so that it reads back unchanged from the database. MarshalJSON, MapForRequest,
+// Coalesce and IsEmpty treat it as empty, marshalling it as null as in previous versions: compare Valid directly,
+// or use Equal, where an empty string must be kept
 type NullString struct {
 	sql.NullString
 }
 
-// MarshalJSON marshals models.NullString datatype
+// IsValid returns true if models.NullString is valid
+func (ns NullString) IsValid() bool {
+	return ns.Valid
+}
+
+// MarshalJSON marshals models.NullString datatype, a valid empty string being marshalled as null
 func (ns NullString) MarshalJSON() ([]byte, error) {
 	if !ns.Valid || ns.String == "" {
 		return []byte("null"), nil
@@ -358,6 +886,7 @@ func (ns *NullString) UnmarshalJSON(b []byte) error {
 }
 
 // Scan scans any variable types to models.NullString datatype
+// An empty string is scanned as valid, so that a value written with Value reads back unchanged
 func (ns *NullString) Scan(value interface{}) error {
 	if reflect.TypeOf(value) == nil {
 		*ns = NullString{}
@@ -369,12 +898,17 @@ func (ns *NullString) Scan(value interface{}) error {
 	}
 
 	ns.NullString = i
-	if i.Valid && i.String == "" {
-		ns.Valid = false
-	}
 	return nil
 }
 
+// Value implements the driver.Valuer interface, writing the stored value even when empty
+func (ns NullString) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return ns.String, nil
+}
+
 // IsEmpty returns true if models.NullString is either not valid or empty
 func (ns NullString) IsEmpty() bool {
 	if !ns.Valid || ns.String == "" {
@@ -393,11 +927,106 @@ func (ns NullString) MapToString() *string {
 	return &value
 }
 
+// MapForRequest returns NullString value if valid and not empty, nil otherwise
+func (ns NullString) MapForRequest() interface{} {
+	if ns.IsEmpty() {
+		return nil
+	}
+	return ns.String
+}
+
+// Coalesce returns NullString value if valid and not empty, def otherwise
+func (ns NullString) Coalesce(def string) string {
+	if ns.IsEmpty() {
+		return def
+	}
+	return ns.String
+}
+
+// Equal returns true if both NullString are invalid, or both are valid with the same value
+func (ns NullString) Equal(other NullString) bool {
+	if !ns.Valid || !other.Valid {
+		return ns.Valid == other.Valid
+	}
+	return ns.String == other.String
+}
+
+// EqualFold is Equal with case-insensitive comparison of values, under Unicode case-folding
+func (ns NullString) EqualFold(other NullString) bool {
+	if !ns.Valid || !other.Valid {
+		return ns.Valid == other.Valid
+	}
+	return strings.EqualFold(ns.String, other.String)
+}
+
+// CoalesceNullStrings returns the value of the first valid and not empty NullString of ns, def if there is none
+func CoalesceNullStrings(def string, ns ...NullString) string {
+	for _, value := range ns {
+		if !value.IsEmpty() {
+			return value.String
+		}
+	}
+	return def
+}
+
+// MapNullStrings returns the string values of valid NullString of ns, invalid ones being dropped
+// Valid empty strings are kept
+func MapNullStrings(ns []NullString) []string {
+	values := make([]string, 0, len(ns))
+	for _, value := range ns {
+		if value.Valid {
+			values = append(values, value.String)
+		}
+	}
+	return values
+}
+
+// likeEscaper escapes LIKE wildcards and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ToLikePattern returns NullString value wrapped as a LIKE pattern matching it anywhere, e.g. "%foo%",
+// "%", "_" and "\" being escaped with a backslash so that they match literally
+// The query must declare the escape character, e.g. "name LIKE $1 ESCAPE '\'", as SQLite and SQL Server have none by default
+// and would otherwise read the backslashes literally. MySQL and PostgreSQL use a backslash by default
+// Returns an empty string if models.NullString is either not valid or empty
+func (ns NullString) ToLikePattern() string {
+	if ns.IsEmpty() {
+		return ""
+	}
+	return "%" + likeEscaper.Replace(ns.String) + "%"
+}
+
+// ValidateMaxLength returns a BadRequestValueError referencing key if models.NullString is valid and longer than maxLength runes
+// The value itself is left out of the error, so that long user input is not echoed back
+func (ns NullString) ValidateMaxLength(key string, maxLength int) error {
+	if ns.IsEmpty() {
+		return nil
+	}
+
+	if length := utf8.RuneCountInString(ns.String); length > maxLength {
+		return BadRequestValueError{
+			Key: key,
+			Err: fmt.Errorf("length (%d) exceeds max length (%d)", length, maxLength),
+		}
+	}
+	return nil
+}
+
 // NullEmptyString encapsulates sql null string with custom marshalling/unmarshalling to allow empty string
 type NullEmptyString struct {
 	sql.NullString
 }
 
+// IsValid returns true if models.NullEmptyString is valid
+func (ns NullEmptyString) IsValid() bool {
+	return ns.Valid
+}
+
+// IsEmpty returns true if models.NullEmptyString is either not valid or empty
+func (ns NullEmptyString) IsEmpty() bool {
+	return !ns.Valid || ns.String == ""
+}
+
 // MarshalJSON marshals models.NullEmptyString datatype
 func (ns NullEmptyString) MarshalJSON() ([]byte, error) {
 	if !ns.Valid {
@@ -413,12 +1042,186 @@ func (ns *NullEmptyString) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// NullStringSlice encapsulates a nullable slice of strings, scanned from Postgres text[] or JSON array columns
+// A valid empty slice is distinct from an invalid one: the former is marshalled as [], the latter as null
+type NullStringSlice struct {
+	Strings []string
+	Valid   bool
+}
+
+// IsValid returns true if models.NullStringSlice is valid
+func (ns NullStringSlice) IsValid() bool {
+	return ns.Valid
+}
+
+// IsEmpty returns true if models.NullStringSlice is either not valid or empty
+func (ns NullStringSlice) IsEmpty() bool {
+	return !ns.Valid || len(ns.Strings) == 0
+}
+
+// MarshalJSON marshals models.NullStringSlice datatype as a JSON array, or null when not valid
+func (ns NullStringSlice) MarshalJSON() ([]byte, error) {
+	if !ns.Valid {
+		return []byte("null"), nil
+	}
+	if ns.Strings == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(ns.Strings)
+}
+
+// UnmarshalJSON unmarshals models.NullStringSlice datatype
+func (ns *NullStringSlice) UnmarshalJSON(b []byte) error {
+	var x *[]string
+	if err := json.Unmarshal(b, &x); err != nil {
+		return err
+	}
+	if x == nil {
+		*ns = NullStringSlice{}
+		return nil
+	}
+	ns.Strings, ns.Valid = *x, true
+	if ns.Strings == nil {
+		ns.Strings = []string{}
+	}
+	return nil
+}
+
+// Scan scans Postgres array literals ({a,b,"c d"}) and JSON arrays (["a","b"]) to models.NullStringSlice datatype
+func (ns *NullStringSlice) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		*ns = NullStringSlice{}
+		return nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("could not scan %T into NullStringSlice", value)
+	}
+
+	s = strings.TrimSpace(s)
+	var values []string
+	var err error
+	switch {
+	case strings.HasPrefix(s, "{"):
+		values, err = parseArrayLiteral(s)
+	case strings.HasPrefix(s, "["):
+		err = json.Unmarshal([]byte(s), &values)
+	default:
+		err = errors.New("not an array literal nor a JSON array")
+	}
+	if err != nil {
+		return fmt.Errorf("could not scan %q into NullStringSlice: %w", s, err)
+	}
+
+	if values == nil {
+		values = []string{}
+	}
+	ns.Strings, ns.Valid = values, true
+	return nil
+}
+
+// Value implements the driver.Valuer interface, writing a Postgres array literal
+func (ns NullStringSlice) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+
+	quoted := make([]string, 0, len(ns.Strings))
+	for _, value := range ns.Strings {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+		value = strings.ReplaceAll(value, `"`, `\"`)
+		quoted = append(quoted, `"`+value+`"`)
+	}
+	return "{" + strings.Join(quoted, ",") + "}", nil
+}
+
+// MapToStrings returns NullStringSlice value if valid, nil otherwise
+func (ns NullStringSlice) MapToStrings() []string {
+	if !ns.Valid {
+		return nil
+	}
+	return append([]string{}, ns.Strings...)
+}
+
+// parseArrayLiteral parses a one-dimensional Postgres array literal such as {a,b,"c d"}
+// Elements may be double-quoted with backslash escapes, unquoted NULL elements are rejected
+func parseArrayLiteral(s string) ([]string, error) {
+	if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+		return nil, errors.New("array literal must be enclosed in braces")
+	}
+
+	body := s[1 : len(s)-1]
+	values := []string{}
+	if strings.TrimSpace(body) == "" {
+		return values, nil
+	}
+
+	var current strings.Builder
+	quoted, inQuotes, escaped := false, false, false
+	flush := func() error {
+		value := current.String()
+		if !quoted {
+			value = strings.TrimSpace(value)
+			if strings.EqualFold(value, "NULL") {
+				return errors.New("NULL elements are not supported")
+			}
+		}
+		values = append(values, value)
+		current.Reset()
+		quoted = false
+		return nil
+	}
+
+	for _, r := range body {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+			quoted = true
+		case r == ',' && !inQuotes:
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		case r == '{' && !inQuotes:
+			return nil, errors.New("multi-dimensional arrays are not supported")
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if inQuotes || escaped {
+		return nil, errors.New("unterminated quoted element")
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
 // NullTime encapsulates sql null time with custom marshalling/unmarshalling
 type NullTime struct {
 	sql.NullTime
 }
 
-// MarshalJSON marshals models.NullTime datatype
+// IsValid returns true if models.NullTime is valid
+func (nt NullTime) IsValid() bool {
+	return nt.Valid
+}
+
+// IsEmpty returns true if models.NullTime is either not valid or zero
+func (nt NullTime) IsEmpty() bool {
+	return !nt.Valid || nt.Time.IsZero()
+}
+
+// MarshalJSON marshals models.NullTime datatype, a valid zero time being marshalled as null, see NullTimeKeepZero
 func (nt NullTime) MarshalJSON() ([]byte, error) {
 	if !nt.Valid || nt.Time.IsZero() {
 		return []byte("null"), nil
@@ -426,47 +1229,97 @@ func (nt NullTime) MarshalJSON() ([]byte, error) {
 	return json.Marshal(nt.Time.Format("2006-01-02"))
 }
 
+// NullTimeKeepZero is a NullTime marshalling a valid zero time as a date, e.g. for audit logs, null being kept for invalid ones only
+type NullTimeKeepZero struct {
+	NullTime
+}
+
+// MarshalJSON marshals models.NullTimeKeepZero datatype
+func (nt NullTimeKeepZero) MarshalJSON() ([]byte, error) {
+	if !nt.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nt.Time.Format("2006-01-02"))
+}
+
+// Scan scans time.Time value from database as NullTime does, a zero time being kept valid
+func (nt *NullTimeKeepZero) Scan(value interface{}) error {
+	if err := nt.NullTime.Scan(value); err != nil {
+		return err
+	}
+
+	nt.Valid = value != nil
+	return nil
+}
+
 // UnmarshalJSON unmarshal models.NullTime datatype
-// When using Unmarshal method for null time, layout must be either "YYYY-MM-DD" or RFC3339
+// When using Unmarshal method for null time, layout must be one of NullTimeParseLayouts ("YYYY-MM-DD" or RFC3339
+// unless configured), an unquoted number is read as Unix epoch seconds
 func (nt *NullTime) UnmarshalJSON(b []byte) error {
 	if string(b) == "null" {
 		nt.Valid = false
 		return nil
 	}
 
-	var err error
-	nt.Time, err = time.Parse(`"2006-01-02"`, string(b))
-	if err == nil {
-		nt.Valid = true
+	if len(b) > 0 && b[0] != '"' {
+		seconds, err := strconv.ParseInt(string(b), 10, 64)
+		if err != nil {
+			nt.Valid = false
+			return fmt.Errorf("could not parse %s as Unix timestamp: %w", b, err)
+		}
+		nt.Time, nt.Valid = time.Unix(seconds, 0).UTC(), true
 		return nil
 	}
 
-	/* Try RFC3339 format layout */
-	nt.Time, err = time.Parse(`"`+time.RFC3339+`"`, string(b))
-	if err == nil {
-		nt.Valid = true
+	var value string
+	if err := json.Unmarshal(b, &value); err != nil {
+		nt.Valid = false
+		return err
 	}
+
+	var err error
+	nt.Time, err = parseNullTime(value)
+	nt.Valid = err == nil
 	return err
 }
 
+// NullTimeParseLayouts are the layouts tried in order when parsing NullTime from a string, e.g. "02/01/2006" can be appended
+// It is meant to be configured once at startup and is not safe for concurrent modification
+var NullTimeParseLayouts = []string{"2006-01-02", time.RFC3339}
+
+// parseNullTime parses value with the first matching layout of NullTimeParseLayouts
+func parseNullTime(value string) (time.Time, error) {
+	for _, layout := range NullTimeParseLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("could not parse %q with any of layouts %q", value, NullTimeParseLayouts)
+}
+
 // Scan method scans time.Time value from database and forces timezone to UTC
 // Do not use NullTime if you want to scan time from database with database's timezone
+// String and byte slice values, as returned by some drivers for DATE columns, are parsed with NullTimeParseLayouts
 func (nt *NullTime) Scan(value interface{}) error {
 	if reflect.TypeOf(value) == nil {
 		*nt = NullTime{}
 	}
 
+	switch v := value.(type) {
+	case []byte:
+		return nt.scanString(string(v))
+	case string:
+		return nt.scanString(v)
+	}
+
 	var i sql.NullTime
 	if err := i.Scan(value); err != nil {
 		return err
 	}
 
-	zone, _ := i.Time.Zone()
-	if zone != "UTC" {
-		nt.NullTime.Time = time.Date(i.Time.Year(), i.Time.Month(), i.Time.Day(), 0, 0, 0, 0, time.UTC)
-		nt.NullTime.Valid = true
-	} else {
-		nt.NullTime = i
+	nt.NullTime = i
+	if zone, _ := i.Time.Zone(); zone != "UTC" {
+		nt.NullTime.Time, nt.NullTime.Valid = forceUTCDate(i.Time), true
 	}
 
 	if nt.Time.IsZero() {
@@ -476,6 +1329,25 @@ func (nt *NullTime) Scan(value interface{}) error {
 	return nil
 }
 
+// scanString scans string representation of a time to models.NullTime datatype
+func (nt *NullTime) scanString(value string) error {
+	t, err := parseNullTime(value)
+	if err != nil {
+		return fmt.Errorf("could not scan %q into NullTime: %w", value, err)
+	}
+
+	if zone, _ := t.Zone(); zone != "UTC" {
+		t = forceUTCDate(t)
+	}
+	nt.Time, nt.Valid = t, !t.IsZero()
+	return nil
+}
+
+// forceUTCDate returns 00:00:00 UTC on the date of t in its own location, as Scan does for non UTC times
+func forceUTCDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
 // MapToString returns NullTime string value pointer (RFC3339) if valid , nil otherwise
 func (nt NullTime) MapToString() *string {
 	if !nt.Valid {
@@ -486,6 +1358,58 @@ func (nt NullTime) MapToString() *string {
 	return &value
 }
 
+// In returns NullTime converted to loc if valid, invalid NullTime being returned as is
+func (nt NullTime) In(loc *time.Location) NullTime {
+	if !nt.Valid {
+		return nt
+	}
+	return NullTime{sql.NullTime{Time: nt.Time.In(loc), Valid: true}}
+}
+
+// TruncateToDay returns NullTime at 00:00:00 UTC on the same date if valid, invalid NullTime being returned as is
+// As in Scan, the date is the one of the time in its own location
+func (nt NullTime) TruncateToDay() NullTime {
+	if !nt.Valid {
+		return nt
+	}
+
+	return NullTime{sql.NullTime{Time: forceUTCDate(nt.Time), Valid: true}}
+}
+
+// MapToStringIn returns NullTime string value pointer (RFC3339) converted to loc if valid, nil otherwise
+func (nt NullTime) MapToStringIn(loc *time.Location) *string {
+	return nt.In(loc).MapToString()
+}
+
+// MapForRequest returns NullTime time.Time value in UTC if valid, nil otherwise, ready to be used as a query argument
+func (nt NullTime) MapForRequest() interface{} {
+	if !nt.Valid {
+		return nil
+	}
+	return nt.Time.UTC()
+}
+
+// Now returns the current time used by NullTime.IsFuture and NullTime.IsPast, it can be replaced in tests
+var Now = time.Now
+
+// IsFuture returns true if NullTime is valid and after the current UTC time
+func (nt NullTime) IsFuture() bool {
+	return nt.Valid && nt.Time.After(Now().UTC())
+}
+
+// IsPast returns true if NullTime is valid and before the current UTC time
+func (nt NullTime) IsPast() bool {
+	return nt.Valid && nt.Time.Before(Now().UTC())
+}
+
+// Age returns the duration elapsed since NullTime according to Now, negative for a future time, and false if invalid
+func (nt NullTime) Age() (time.Duration, bool) {
+	if !nt.Valid {
+		return 0, false
+	}
+	return Now().Sub(nt.Time), true
+}
+
 // AfterOrEqual returns true if variable is equal or after arg.
 func (nt NullTime) AfterOrEqual(t NullTime) bool {
 	if !nt.Time.IsZero() && t.Time.IsZero() {
@@ -506,11 +1430,167 @@ func (nt NullTime) BeforeOrEqual(t NullTime) bool {
 	return nt.Time == t.Time || nt.Time.Before(t.Time)
 }
 
+// RangesOverlap returns true if ranges [startA, endA] and [startB, endB] intersect.
+// Ranges are closed intervals: bounds are inclusive, so adjacent ranges (endA equal to startB) overlap.
+// An invalid start means the range has no lower bound, an invalid end means it has no upper bound.
+func RangesOverlap(startA, endA, startB, endB NullTime) bool {
+	return startsBeforeEnd(startA, endB) && startsBeforeEnd(startB, endA)
+}
+
+// startsBeforeEnd returns true if start is before or equal to end, invalid bounds being open-ended
+func startsBeforeEnd(start, end NullTime) bool {
+	if !start.Valid || !end.Valid {
+		return true
+	}
+	return !start.Time.After(end.Time)
+}
+
+// BucketUnit defines the period NullTime.Bucket groups times by
+type BucketUnit int
+
+const (
+	// BucketDay groups times by day, e.g. "2023-01-15"
+	BucketDay BucketUnit = iota
+	// BucketWeek groups times by ISO 8601 week, e.g. "2023-W03"
+	BucketWeek
+	// BucketMonth groups times by month, e.g. "2023-01"
+	BucketMonth
+	// BucketYear groups times by year, e.g. "2023"
+	BucketYear
+)
+
+// Bucket returns the grouping key of the period of unit holding NullTime, in its own location
+// Returns an invalid NullString if models.NullTime is not valid or unit is unknown
+func (nt NullTime) Bucket(unit BucketUnit) NullString {
+	if !nt.Valid {
+		return NullString{}
+	}
+
+	var key string
+	switch unit {
+	case BucketDay:
+		key = nt.Time.Format("2006-01-02")
+	case BucketWeek:
+		year, week := nt.Time.ISOWeek()
+		key = fmt.Sprintf("%04d-W%02d", year, week)
+	case BucketMonth:
+		key = nt.Time.Format("2006-01")
+	case BucketYear:
+		key = nt.Time.Format("2006")
+	default:
+		return NullString{}
+	}
+	return NullString{sql.NullString{String: key, Valid: true}}
+}
+
+// ToDateTime converts models.NullTime to models.NullDateTime, keeping its time as is
+func (nt NullTime) ToDateTime() NullDateTime {
+	return NullDateTime{nt.NullTime}
+}
+
+// NullDateTime encapsulates sql null time with custom marshalling/unmarshalling keeping the clock component
+// Unlike NullTime, which is date-only, it is marshalled as RFC3339 and scanned without truncation to the date
+type NullDateTime struct {
+	sql.NullTime
+}
+
+// IsValid returns true if models.NullDateTime is valid
+func (ndt NullDateTime) IsValid() bool {
+	return ndt.Valid
+}
+
+// IsEmpty returns true if models.NullDateTime is either not valid or zero
+func (ndt NullDateTime) IsEmpty() bool {
+	return !ndt.Valid || ndt.Time.IsZero()
+}
+
+// MarshalJSON marshals models.NullDateTime datatype as RFC3339, or null when not valid
+func (ndt NullDateTime) MarshalJSON() ([]byte, error) {
+	if !ndt.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ndt.Time.Format(time.RFC3339))
+}
+
+// UnmarshalJSON unmarshals models.NullDateTime datatype, accepting the same inputs as NullTime.UnmarshalJSON
+func (ndt *NullDateTime) UnmarshalJSON(b []byte) error {
+	var nt NullTime
+	err := nt.UnmarshalJSON(b)
+	ndt.NullTime = nt.NullTime
+	return err
+}
+
+// Scan scans time.Time value from database to models.NullDateTime datatype, converted to UTC with its clock component
+// String and byte slice values are parsed with NullTimeParseLayouts
+func (ndt *NullDateTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*ndt = NullDateTime{}
+		return nil
+	case []byte:
+		return ndt.scanString(string(v))
+	case string:
+		return ndt.scanString(v)
+	}
+
+	var i sql.NullTime
+	if err := i.Scan(value); err != nil {
+		return err
+	}
+
+	ndt.Time, ndt.Valid = i.Time.UTC(), !i.Time.IsZero()
+	return nil
+}
+
+// scanString scans string representation of a time to models.NullDateTime datatype
+func (ndt *NullDateTime) scanString(value string) error {
+	t, err := parseNullTime(value)
+	if err != nil {
+		return fmt.Errorf("could not scan %q into NullDateTime: %w", value, err)
+	}
+
+	ndt.Time, ndt.Valid = t.UTC(), !t.IsZero()
+	return nil
+}
+
+// ToDate converts models.NullDateTime to models.NullTime, truncating its UTC time to the date
+func (ndt NullDateTime) ToDate() NullTime {
+	if !ndt.Valid {
+		return NullTime{}
+	}
+
+	t := ndt.Time.UTC()
+	return NullTime{sql.NullTime{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), Valid: true}}
+}
+
+// MapToString returns NullDateTime string value pointer (RFC3339) if valid, nil otherwise
+func (ndt NullDateTime) MapToString() *string {
+	if !ndt.Valid {
+		return nil
+	}
+
+	value := ndt.Time.Format(time.RFC3339)
+	return &value
+}
+
 // JSONNullInt64 encapsulates sql null int with marshalling/unmarshalling
+// encoding/json cannot omit a struct field with omitempty, so an invalid value is marshalled as null.
+// To drop it instead, map it with MapToInt64 into an *int64 field tagged omitempty,
+// or tag the field omitzero (Go 1.24+), which relies on IsZero
 type JSONNullInt64 struct {
 	sql.NullInt64
 }
 
+// IsValid returns true if JSONNullInt64 is valid
+func (v JSONNullInt64) IsValid() bool {
+	return v.Valid
+}
+
+// IsEmpty returns true if JSONNullInt64 is not valid, zero being a meaningful value
+func (v JSONNullInt64) IsEmpty() bool {
+	return !v.Valid
+}
+
 // MarshalJSON marshals models.JSONNullInt64 datatype
 func (v JSONNullInt64) MarshalJSON() ([]byte, error) {
 	if v.Valid {
@@ -542,11 +1622,36 @@ func (v JSONNullInt64) MapForRequest() interface{} {
 	return nil
 }
 
+// MapToInt64 returns JSONNullInt64 value pointer if valid, nil otherwise
+func (v JSONNullInt64) MapToInt64() *int64 {
+	if !v.Valid {
+		return nil
+	}
+	value := v.Int64
+	return &value
+}
+
+// IsZero returns true if JSONNullInt64 is not valid, so that omitzero fields are dropped
+func (v JSONNullInt64) IsZero() bool {
+	return !v.Valid
+}
+
 // JSONNullFloat64 encapsulates sql null float with marshalling/unmarshalling
+// As for JSONNullInt64, use MapToFloat64 with an omitempty *float64 field or omitzero (Go 1.24+) to drop invalid values
 type JSONNullFloat64 struct {
 	sql.NullFloat64
 }
 
+// IsValid returns true if JSONNullFloat64 is valid
+func (v JSONNullFloat64) IsValid() bool {
+	return v.Valid
+}
+
+// IsEmpty returns true if JSONNullFloat64 is not valid, zero being a meaningful value
+func (v JSONNullFloat64) IsEmpty() bool {
+	return !v.Valid
+}
+
 // MarshalJSON marshals JSONNullFloat64 datatype
 func (v JSONNullFloat64) MarshalJSON() ([]byte, error) {
 	if v.Valid {
@@ -577,3 +1682,17 @@ func (v JSONNullFloat64) MapForRequest() interface{} {
 	}
 	return nil
 }
+
+// MapToFloat64 returns JSONNullFloat64 value pointer if valid, nil otherwise
+func (v JSONNullFloat64) MapToFloat64() *float64 {
+	if !v.Valid {
+		return nil
+	}
+	value := v.Float64
+	return &value
+}
+
+// IsZero returns true if JSONNullFloat64 is not valid, so that omitzero fields are dropped
+func (v JSONNullFloat64) IsZero() bool {
+	return !v.Valid
+}
@@ -0,0 +1,1252 @@
+package pagination
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validNullTime(year int, month time.Month, day int) NullTime {
+	return NullTime{sql.NullTime{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Valid: true}}
+}
+
+func TestRangesOverlap(t *testing.T) {
+	tests := map[string]struct {
+		startA NullTime
+		endA   NullTime
+		startB NullTime
+		endB   NullTime
+		want   bool
+	}{
+		"when ranges are disjoint, returns false": {
+			startA: validNullTime(2023, 1, 1),
+			endA:   validNullTime(2023, 1, 10),
+			startB: validNullTime(2023, 1, 11),
+			endB:   validNullTime(2023, 1, 20),
+			want:   false,
+		},
+		"when ranges are adjacent, returns true": {
+			startA: validNullTime(2023, 1, 1),
+			endA:   validNullTime(2023, 1, 10),
+			startB: validNullTime(2023, 1, 10),
+			endB:   validNullTime(2023, 1, 20),
+			want:   true,
+		},
+		"when a range contains the other, returns true": {
+			startA: validNullTime(2023, 1, 1),
+			endA:   validNullTime(2023, 1, 31),
+			startB: validNullTime(2023, 1, 10),
+			endB:   validNullTime(2023, 1, 20),
+			want:   true,
+		},
+		"when first range has no upper bound and starts after second range, returns false": {
+			startA: validNullTime(2023, 2, 1),
+			endA:   NullTime{},
+			startB: validNullTime(2023, 1, 1),
+			endB:   validNullTime(2023, 1, 31),
+			want:   false,
+		},
+		"when first range has no upper bound and starts before second range end, returns true": {
+			startA: validNullTime(2023, 1, 15),
+			endA:   NullTime{},
+			startB: validNullTime(2023, 1, 1),
+			endB:   validNullTime(2023, 1, 31),
+			want:   true,
+		},
+		"when second range has no lower bound and ends before first range, returns false": {
+			startA: validNullTime(2023, 2, 1),
+			endA:   validNullTime(2023, 2, 28),
+			startB: NullTime{},
+			endB:   validNullTime(2023, 1, 31),
+			want:   false,
+		},
+		"when both ranges are unbounded, returns true": {
+			want: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RangesOverlap(tt.startA, tt.endA, tt.startB, tt.endB))
+			assert.Equal(t, tt.want, RangesOverlap(tt.startB, tt.endB, tt.startA, tt.endA))
+		})
+	}
+}
+
+func TestCheckRowsAffected(t *testing.T) {
+	t.Run("when affected matches expected, returns nil", func(t *testing.T) {
+		assert.NoError(t, CheckRowsAffected("update", 3, 2, 2))
+	})
+
+	t.Run("when affected differs from expected, returns RowsAffectedError", func(t *testing.T) {
+		err := CheckRowsAffected("update", 3, 0, 1)
+
+		var rowsErr RowsAffectedError
+		require.ErrorAs(t, err, &rowsErr)
+		assert.Equal(t, RowsAffectedError{Usecase: "update", VersionID: 3, AffectedRows: 0, ExpectedRows: 1}, rowsErr)
+		assert.EqualError(t, err, "err in repository for usecase update: 0 affected rows, 1 was expected")
+	})
+}
+
+func TestMapForRequest(t *testing.T) {
+	tests := map[string]struct {
+		value interface{ MapForRequest() interface{} }
+		want  interface{}
+	}{
+		"valid NullInt returns int64":       {value: NullInt{sql.NullInt64{Int64: 42, Valid: true}}, want: int64(42)},
+		"zero NullInt returns nil":          {value: NullInt{sql.NullInt64{Int64: 0, Valid: true}}, want: nil},
+		"invalid NullInt returns nil":       {value: NullInt{}, want: nil},
+		"valid NullFloat returns float64":   {value: NullFloat{sql.NullFloat64{Float64: 1.5, Valid: true}}, want: 1.5},
+		"invalid NullFloat returns nil":     {value: NullFloat{}, want: nil},
+		"valid NullString returns string":   {value: NullString{sql.NullString{String: "hello", Valid: true}}, want: "hello"},
+		"empty NullString returns nil":      {value: NullString{sql.NullString{String: "", Valid: true}}, want: nil},
+		"invalid NullString returns nil":    {value: NullString{}, want: nil},
+		"valid NullTime returns UTC time":   {value: validNullTime(2023, 1, 2), want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		"non UTC NullTime returns UTC time": {value: validNullTime(2023, 1, 2).In(time.FixedZone("Paris", 3600)), want: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		"invalid NullTime returns nil":      {value: NullTime{}, want: nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.value.MapForRequest())
+		})
+	}
+
+	t.Run("valid NullTime returns a time.Time, not a string", func(t *testing.T) {
+		assert.IsType(t, time.Time{}, validNullTime(2023, 1, 2).MapForRequest())
+	})
+}
+
+func TestMapForRequestZero(t *testing.T) {
+	tests := map[string]struct {
+		value interface{ MapForRequestZero() interface{} }
+		want  interface{}
+	}{
+		"valid NullInt returns int64":     {value: NullInt{sql.NullInt64{Int64: 42, Valid: true}}, want: int64(42)},
+		"zero NullInt returns 0":          {value: NullInt{sql.NullInt64{Int64: 0, Valid: true}}, want: int64(0)},
+		"invalid NullInt returns nil":     {value: NullInt{}, want: nil},
+		"valid NullFloat returns float64": {value: NullFloat{sql.NullFloat64{Float64: 1.5, Valid: true}}, want: 1.5},
+		"zero NullFloat returns 0":        {value: NullFloat{sql.NullFloat64{Float64: 0, Valid: true}}, want: float64(0)},
+		"invalid NullFloat returns nil":   {value: NullFloat{}, want: nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.value.MapForRequestZero())
+		})
+	}
+}
+
+func TestNullable(t *testing.T) {
+	t.Run("valid and non empty values", func(t *testing.T) {
+		values := []Nullable{
+			NullBool{sql.NullBool{Bool: true, Valid: true}},
+			NullInt{sql.NullInt64{Int64: 1, Valid: true}},
+			NullFloat{sql.NullFloat64{Float64: 1.5, Valid: true}},
+			NullString{sql.NullString{String: "hello", Valid: true}},
+			NullEmptyString{sql.NullString{String: "hello", Valid: true}},
+			validNullTime(2023, 1, 2),
+			JSONNullInt64{sql.NullInt64{Int64: 0, Valid: true}},
+			JSONNullFloat64{sql.NullFloat64{Float64: 0, Valid: true}},
+			NullInt32{sql.NullInt32{Int32: 0, Valid: true}},
+			NullInt16{sql.NullInt16{Int16: 0, Valid: true}},
+			NullStringSlice{Strings: []string{"a"}, Valid: true},
+			NullDateTime{sql.NullTime{Time: time.Date(2023, 1, 2, 10, 30, 0, 0, time.UTC), Valid: true}},
+		}
+		for _, value := range values {
+			assert.True(t, value.IsValid(), "%T should be valid", value)
+			assert.False(t, value.IsEmpty(), "%T should not be empty", value)
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		values := []Nullable{
+			NullBool{},
+			NullInt{},
+			NullFloat{},
+			NullString{},
+			NullEmptyString{},
+			NullTime{},
+			JSONNullInt64{},
+			JSONNullFloat64{},
+			NullInt32{},
+			NullInt16{},
+			NullStringSlice{},
+			NullDateTime{},
+		}
+		for _, value := range values {
+			assert.False(t, value.IsValid(), "%T should not be valid", value)
+			assert.True(t, value.IsEmpty(), "%T should be empty", value)
+		}
+	})
+}
+
+func TestNullBoolScan(t *testing.T) {
+	tests := map[string]struct {
+		value   interface{}
+		want    NullBool
+		wantErr bool
+	}{
+		"nil is invalid":          {value: nil, want: NullBool{}},
+		"bool true":               {value: true, want: NullBool{sql.NullBool{Bool: true, Valid: true}}},
+		"bool false":              {value: false, want: NullBool{sql.NullBool{Bool: false, Valid: true}}},
+		"int64 zero is false":     {value: int64(0), want: NullBool{sql.NullBool{Bool: false, Valid: true}}},
+		"int64 one is true":       {value: int64(1), want: NullBool{sql.NullBool{Bool: true, Valid: true}}},
+		"int64 nonzero is true":   {value: int64(-3), want: NullBool{sql.NullBool{Bool: true, Valid: true}}},
+		"bytes one is true":       {value: []byte("1"), want: NullBool{sql.NullBool{Bool: true, Valid: true}}},
+		"bytes zero is false":     {value: []byte("0"), want: NullBool{sql.NullBool{Bool: false, Valid: true}}},
+		"string true":             {value: "true", want: NullBool{sql.NullBool{Bool: true, Valid: true}}},
+		"string false":            {value: "false", want: NullBool{sql.NullBool{Bool: false, Valid: true}}},
+		"unknown string errors":   {value: "yes", wantErr: true},
+		"unknown bytes errors":    {value: []byte("2"), wantErr: true},
+		"unsupported type errors": {value: 1.5, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var nb NullBool
+			err := nb.Scan(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, nb.Valid)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, nb)
+		})
+	}
+}
+
+func TestNullIntAdd(t *testing.T) {
+	t.Run("incrementing invalid starts from zero", func(t *testing.T) {
+		assert.Equal(t, NullInt{sql.NullInt64{Int64: 1, Valid: true}}, NullInt{}.Add(1))
+		assert.Equal(t, NullInt{sql.NullInt64{Int64: 0, Valid: true}}, NullInt{}.Add(0))
+	})
+
+	t.Run("crossing through zero keeps validity", func(t *testing.T) {
+		counter := NullInt{sql.NullInt64{Int64: 1, Valid: true}}
+
+		counter = counter.Add(-1)
+		assert.Equal(t, NullInt{sql.NullInt64{Int64: 0, Valid: true}}, counter)
+
+		counter = counter.Add(-1)
+		assert.Equal(t, NullInt{sql.NullInt64{Int64: -1, Valid: true}}, counter)
+	})
+
+	t.Run("ValueOr", func(t *testing.T) {
+		assert.Equal(t, int64(42), NullInt{}.ValueOr(42))
+		assert.Equal(t, int64(0), NullInt{sql.NullInt64{Int64: 0, Valid: true}}.ValueOr(42))
+		assert.Equal(t, int64(3), NullInt{sql.NullInt64{Int64: 3, Valid: true}}.ValueOr(42))
+	})
+}
+
+func TestNullIntFlags(t *testing.T) {
+	const (
+		read  int64 = 1 << 0
+		write int64 = 1 << 1
+		admin int64 = 1 << 2
+	)
+
+	t.Run("set and check flags", func(t *testing.T) {
+		perms := NullInt{}.SetFlag(read).SetFlag(write)
+
+		assert.True(t, perms.Valid)
+		assert.Equal(t, read|write, perms.Int64)
+		assert.True(t, perms.HasFlag(read))
+		assert.True(t, perms.HasFlag(read|write))
+		assert.False(t, perms.HasFlag(admin))
+		assert.False(t, perms.HasFlag(read|admin))
+	})
+
+	t.Run("clearing all flags keeps a valid zero", func(t *testing.T) {
+		perms := NullInt{}.SetFlag(read | write).ClearFlag(read).ClearFlag(write)
+
+		assert.Equal(t, NullInt{sql.NullInt64{Int64: 0, Valid: true}}, perms)
+		assert.False(t, perms.HasFlag(read))
+	})
+
+	t.Run("invalid NullInt", func(t *testing.T) {
+		assert.False(t, NullInt{}.HasFlag(read))
+		assert.False(t, NullInt{sql.NullInt64{Int64: read, Valid: false}}.HasFlag(read))
+		assert.Equal(t, NullInt{}, NullInt{}.ClearFlag(read))
+	})
+
+	t.Run("setting a flag on an invalid NullInt ignores its stale value", func(t *testing.T) {
+		perms := NullInt{sql.NullInt64{Int64: admin}}.SetFlag(read)
+
+		assert.Equal(t, NullInt{sql.NullInt64{Int64: read, Valid: true}}, perms)
+	})
+}
+
+func TestNullInt32(t *testing.T) {
+	t.Run("Scan", func(t *testing.T) {
+		tests := map[string]struct {
+			value   interface{}
+			want    NullInt32
+			wantErr bool
+		}{
+			"nil is invalid":          {value: nil, want: NullInt32{}},
+			"zero is valid":           {value: int64(0), want: NullInt32{sql.NullInt32{Int32: 0, Valid: true}}},
+			"max value is valid":      {value: int64(math.MaxInt32), want: NullInt32{sql.NullInt32{Int32: math.MaxInt32, Valid: true}}},
+			"min value is valid":      {value: int64(math.MinInt32), want: NullInt32{sql.NullInt32{Int32: math.MinInt32, Valid: true}}},
+			"above max value errors":  {value: int64(math.MaxInt32 + 1), wantErr: true},
+			"below min value errors":  {value: int64(math.MinInt32 - 1), wantErr: true},
+			"unparsable value errors": {value: "pouet", wantErr: true},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				var ni NullInt32
+				err := ni.Scan(tt.value)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, ni)
+			})
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		value, err := NullInt32{sql.NullInt32{Int32: 0, Valid: true}}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), value)
+
+		value, err = NullInt32{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("JSON round trip preserves zero", func(t *testing.T) {
+		b, err := json.Marshal(NullInt32{sql.NullInt32{Int32: 0, Valid: true}})
+		require.NoError(t, err)
+		assert.Equal(t, "0", string(b))
+
+		var ni NullInt32
+		require.NoError(t, json.Unmarshal(b, &ni))
+		assert.True(t, ni.Valid)
+		assert.Equal(t, int32(0), ni.Int32)
+
+		require.NoError(t, json.Unmarshal([]byte("null"), &ni))
+		assert.False(t, ni.Valid)
+		assert.Error(t, json.Unmarshal([]byte("2147483648"), &ni))
+	})
+
+	t.Run("MapToInt32", func(t *testing.T) {
+		assert.Nil(t, NullInt32{}.MapToInt32())
+		value := NullInt32{sql.NullInt32{Int32: 12, Valid: true}}.MapToInt32()
+		require.NotNil(t, value)
+		assert.Equal(t, int32(12), *value)
+	})
+}
+
+func TestNullInt16(t *testing.T) {
+	t.Run("Scan", func(t *testing.T) {
+		tests := map[string]struct {
+			value   interface{}
+			want    NullInt16
+			wantErr bool
+		}{
+			"nil is invalid":         {value: nil, want: NullInt16{}},
+			"zero is valid":          {value: int64(0), want: NullInt16{sql.NullInt16{Int16: 0, Valid: true}}},
+			"max value is valid":     {value: int64(math.MaxInt16), want: NullInt16{sql.NullInt16{Int16: math.MaxInt16, Valid: true}}},
+			"min value is valid":     {value: int64(math.MinInt16), want: NullInt16{sql.NullInt16{Int16: math.MinInt16, Valid: true}}},
+			"above max value errors": {value: int64(math.MaxInt16 + 1), wantErr: true},
+			"below min value errors": {value: int64(math.MinInt16 - 1), wantErr: true},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				var ni NullInt16
+				err := ni.Scan(tt.value)
+				if tt.wantErr {
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, ni)
+			})
+		}
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		value, err := NullInt16{sql.NullInt16{Int16: math.MaxInt16, Valid: true}}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(math.MaxInt16), value)
+
+		value, err = NullInt16{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("JSON round trip preserves zero", func(t *testing.T) {
+		b, err := json.Marshal(NullInt16{sql.NullInt16{Int16: 0, Valid: true}})
+		require.NoError(t, err)
+		assert.Equal(t, "0", string(b))
+
+		var ni NullInt16
+		require.NoError(t, json.Unmarshal(b, &ni))
+		assert.True(t, ni.Valid)
+		assert.Equal(t, int16(0), ni.Int16)
+
+		b, err = json.Marshal(NullInt16{})
+		require.NoError(t, err)
+		assert.Equal(t, "null", string(b))
+		assert.Error(t, json.Unmarshal([]byte("32768"), &ni))
+	})
+
+	t.Run("MapToInt16", func(t *testing.T) {
+		assert.Nil(t, NullInt16{}.MapToInt16())
+		value := NullInt16{sql.NullInt16{Int16: 12, Valid: true}}.MapToInt16()
+		require.NotNil(t, value)
+		assert.Equal(t, int16(12), *value)
+	})
+}
+
+func TestNullTimeUnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    NullTime
+		wantErr bool
+	}{
+		"null is invalid": {
+			input: "null",
+			want:  NullTime{},
+		},
+		"date layout": {
+			input: `"2023-01-02"`,
+			want:  validNullTime(2023, 1, 2),
+		},
+		"RFC3339 layout": {
+			input: `"2023-01-02T00:00:00Z"`,
+			want:  validNullTime(2023, 1, 2),
+		},
+		"Unix epoch seconds": {
+			input: "1672617600",
+			want:  validNullTime(2023, 1, 2),
+		},
+		"Unix epoch zero": {
+			input: "0",
+			want:  NullTime{sql.NullTime{Time: time.Unix(0, 0).UTC(), Valid: true}},
+		},
+		"unquoted garbage errors": {
+			input:   "12.5",
+			wantErr: true,
+		},
+		"unknown layout errors": {
+			input:   `"02/01/2023"`,
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var nt NullTime
+			err := json.Unmarshal([]byte(tt.input), &nt)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, nt.Valid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Valid, nt.Valid)
+			assert.True(t, tt.want.Time.Equal(nt.Time), "expected %v, got %v", tt.want.Time, nt.Time)
+		})
+	}
+}
+
+func TestNullFloatComparisons(t *testing.T) {
+	t.Run("invalid is never greater, less or equal", func(t *testing.T) {
+		invalid := NullFloat{}
+
+		assert.False(t, invalid.GreaterThan(-1))
+		assert.False(t, invalid.LessThan(1))
+		assert.False(t, invalid.EqualTo(0))
+	})
+
+	t.Run("valid values are compared", func(t *testing.T) {
+		value := NullFloat{sql.NullFloat64{Float64: 1.5, Valid: true}}
+
+		assert.True(t, value.GreaterThan(1))
+		assert.False(t, value.GreaterThan(1.5))
+		assert.True(t, value.LessThan(2))
+		assert.False(t, value.LessThan(1.5))
+		assert.True(t, value.EqualTo(1.5))
+		assert.False(t, value.EqualTo(1.6))
+	})
+
+	t.Run("equality holds at the configured scale", func(t *testing.T) {
+		scale := NullFloatScale
+		t.Cleanup(func() { NullFloatScale = scale })
+		NullFloatScale = 4
+
+		var nf NullFloat
+		require.NoError(t, nf.Scan(3.14159265))
+
+		assert.True(t, nf.EqualTo(3.14159265))
+		assert.True(t, nf.EqualTo(3.1415))
+		assert.False(t, nf.EqualTo(3.1416))
+	})
+}
+
+func TestNullFloatRounding(t *testing.T) {
+	tests := map[string]struct {
+		mode  RoundingMode
+		value float64
+		want  float64
+	}{
+		"round down truncates":                {mode: RoundDown, value: 1.23455, want: 1.2345},
+		"round down truncates toward zero":    {mode: RoundDown, value: -1.23455, want: -1.2345},
+		"round half up rounds half up":        {mode: RoundHalfUp, value: 1.23455, want: 1.2346},
+		"round half up rounds away from zero": {mode: RoundHalfUp, value: -1.23455, want: -1.2346},
+		"round half up rounds odd half up":    {mode: RoundHalfUp, value: 1.23465, want: 1.2347},
+		"round half even rounds to even up":   {mode: RoundHalfEven, value: 1.23455, want: 1.2346},
+		"round half even rounds to even down": {mode: RoundHalfEven, value: 1.23465, want: 1.2346},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scale, rounding := NullFloatScale, NullFloatRounding
+			t.Cleanup(func() { NullFloatScale, NullFloatRounding = scale, rounding })
+			NullFloatScale, NullFloatRounding = 4, tt.mode
+
+			var nf NullFloat
+			require.NoError(t, nf.Scan(tt.value))
+
+			assert.True(t, nf.Valid)
+			assert.InEpsilon(t, tt.want, nf.Float64, 1e-12)
+		})
+	}
+
+	t.Run("round down is the default", func(t *testing.T) {
+		assert.Equal(t, RoundDown, NullFloatRounding)
+	})
+}
+
+func TestNullTimeParseLayouts(t *testing.T) {
+	layouts := NullTimeParseLayouts
+	t.Cleanup(func() { NullTimeParseLayouts = layouts })
+	NullTimeParseLayouts = append([]string{}, layouts...)
+	NullTimeParseLayouts = append(NullTimeParseLayouts, "02/01/2006", "2006-01-02 15:04:05")
+
+	t.Run("custom layouts are parsed", func(t *testing.T) {
+		var nt NullTime
+		require.NoError(t, json.Unmarshal([]byte(`"02/01/2023"`), &nt))
+		assert.True(t, nt.Valid)
+		assert.True(t, validNullTime(2023, 1, 2).Time.Equal(nt.Time))
+
+		require.NoError(t, json.Unmarshal([]byte(`"2023-01-02 15:04:05"`), &nt))
+		assert.True(t, time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC).Equal(nt.Time))
+	})
+
+	t.Run("default layouts are still parsed", func(t *testing.T) {
+		var nt NullTime
+		require.NoError(t, json.Unmarshal([]byte(`"2023-01-02"`), &nt))
+		assert.True(t, validNullTime(2023, 1, 2).Time.Equal(nt.Time))
+	})
+
+	t.Run("error lists attempted layouts", func(t *testing.T) {
+		var nt NullTime
+		err := json.Unmarshal([]byte(`"2023.01.02"`), &nt)
+		require.Error(t, err)
+		assert.False(t, nt.Valid)
+		for _, layout := range NullTimeParseLayouts {
+			assert.Contains(t, err.Error(), layout)
+		}
+	})
+}
+
+func TestNullFloatScan(t *testing.T) {
+	tests := map[string]struct {
+		scale int
+		value interface{}
+		want  float64
+	}{
+		"scale 2 truncates":                {scale: 2, value: 3.14159265, want: 3.14},
+		"scale 2 truncates toward zero":    {scale: 2, value: -3.14959265, want: -3.14},
+		"scale 4 truncates":                {scale: 4, value: 3.14159265, want: 3.1415},
+		"scale 4 keeps shorter values":     {scale: 4, value: 2.5, want: 2.5},
+		"scale 8 truncates":                {scale: 8, value: 3.1415926535, want: 3.14159265},
+		"scale 8 handles large magnitudes": {scale: 8, value: 123456789012.5, want: 123456789012.5},
+		"scale 2 parses byte slice":        {scale: 2, value: []byte("3.14159"), want: 3.14},
+		"scale 4 parses byte slice":        {scale: 4, value: []byte("3.14159"), want: 3.1415},
+		"scale 4 parses string":            {scale: 4, value: "2.5", want: 2.5},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			scale := NullFloatScale
+			t.Cleanup(func() { NullFloatScale = scale })
+			NullFloatScale = tt.scale
+
+			var nf NullFloat
+			require.NoError(t, nf.Scan(tt.value))
+
+			assert.True(t, nf.Valid)
+			assert.InEpsilon(t, tt.want, nf.Float64, 1e-12)
+		})
+	}
+
+	t.Run("nil is invalid", func(t *testing.T) {
+		var nf NullFloat
+		require.NoError(t, nf.Scan(nil))
+		assert.False(t, nf.Valid)
+	})
+
+	t.Run("unparseable string returns an error", func(t *testing.T) {
+		var nf NullFloat
+		assert.Error(t, nf.Scan([]byte("3,14")))
+		assert.False(t, nf.Valid)
+	})
+}
+
+func TestNullTimeMarshalJSON(t *testing.T) {
+	zero := NullTime{sql.NullTime{Time: time.Time{}, Valid: true}}
+	tests := map[string]struct {
+		value interface{}
+		want  string
+	}{
+		"invalid is null":                        {value: NullTime{}, want: "null"},
+		"valid zero is null":                     {value: zero, want: "null"},
+		"valid date":                             {value: validNullTime(2023, 1, 2), want: `"2023-01-02"`},
+		"invalid is null when keeping zero":      {value: NullTimeKeepZero{}, want: "null"},
+		"valid zero is a date when keeping zero": {value: NullTimeKeepZero{zero}, want: `"0001-01-01"`},
+		"valid date when keeping zero":           {value: NullTimeKeepZero{validNullTime(2023, 1, 2)}, want: `"2023-01-02"`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := json.Marshal(tt.value)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(out))
+		})
+	}
+
+	t.Run("fields pick their own marshalling", func(t *testing.T) {
+		out, err := json.Marshal(struct {
+			DeletedAt NullTime         `json:"deletedAt"`
+			AuditedAt NullTimeKeepZero `json:"auditedAt"`
+		}{DeletedAt: zero, AuditedAt: NullTimeKeepZero{zero}})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"deletedAt":null,"auditedAt":"0001-01-01"}`, string(out))
+	})
+
+	t.Run("NullTimeKeepZero unmarshals and scans as NullTime", func(t *testing.T) {
+		var nt NullTimeKeepZero
+		require.NoError(t, json.Unmarshal([]byte(`"2023-01-02"`), &nt))
+		assert.Equal(t, validNullTime(2023, 1, 2), nt.NullTime)
+
+		require.NoError(t, nt.Scan(time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, validNullTime(2023, 1, 2), nt.NullTime)
+
+		require.NoError(t, nt.Scan(nil))
+		assert.False(t, nt.Valid)
+	})
+
+	t.Run("NullTimeKeepZero scans a zero time as valid and marshals it as a date", func(t *testing.T) {
+		var nt NullTimeKeepZero
+		require.NoError(t, nt.Scan(time.Time{}))
+		assert.True(t, nt.Valid)
+
+		out, err := json.Marshal(nt)
+
+		require.NoError(t, err)
+		assert.Equal(t, `"0001-01-01"`, string(out))
+	})
+}
+
+func TestNullStringValidateMaxLength(t *testing.T) {
+	tests := map[string]struct {
+		value   NullString
+		max     int
+		wantErr bool
+	}{
+		"invalid passes":                        {value: NullString{}, max: 0},
+		"empty passes":                          {value: NullString{sql.NullString{String: "", Valid: true}}, max: 0},
+		"ascii at max length passes":            {value: NullString{sql.NullString{String: "hello", Valid: true}}, max: 5},
+		"ascii above max length fails":          {value: NullString{sql.NullString{String: "hello!", Valid: true}}, max: 5, wantErr: true},
+		"multibyte at max length passes":        {value: NullString{sql.NullString{String: "héllo", Valid: true}}, max: 5},
+		"multibyte above max length fails":      {value: NullString{sql.NullString{String: "héllo!", Valid: true}}, max: 5, wantErr: true},
+		"emojis are counted as a rune each":     {value: NullString{sql.NullString{String: "🙂🙂🙂", Valid: true}}, max: 3},
+		"emojis are counted as a rune and fail": {value: NullString{sql.NullString{String: "🙂🙂🙂🙂", Valid: true}}, max: 3, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.value.ValidateMaxLength("name", tt.max)
+			if tt.wantErr {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, err, &valueErr)
+				assert.Equal(t, "name", valueErr.Key)
+				assert.Equal(t, fmt.Sprintf(`bad request, value from key "name" could not be parsed: "length (%d) exceeds max length (%d)"`,
+					utf8.RuneCountInString(tt.value.String), tt.max), err.Error())
+				assert.NotContains(t, err.Error(), tt.value.String)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNullStringCoalesce(t *testing.T) {
+	valid := NullString{sql.NullString{String: "hello", Valid: true}}
+	empty := NullString{sql.NullString{String: "", Valid: true}}
+
+	t.Run("Coalesce", func(t *testing.T) {
+		assert.Equal(t, "hello", valid.Coalesce("default"))
+		assert.Equal(t, "default", empty.Coalesce("default"))
+		assert.Equal(t, "default", NullString{}.Coalesce("default"))
+	})
+
+	t.Run("CoalesceNullStrings returns first valid value", func(t *testing.T) {
+		other := NullString{sql.NullString{String: "other", Valid: true}}
+
+		assert.Equal(t, "hello", CoalesceNullStrings("default", NullString{}, empty, valid, other))
+	})
+
+	t.Run("CoalesceNullStrings falls back to default", func(t *testing.T) {
+		assert.Equal(t, "default", CoalesceNullStrings("default", NullString{}, empty))
+		assert.Equal(t, "default", CoalesceNullStrings("default"))
+	})
+}
+
+func TestNullStringEqual(t *testing.T) {
+	valid := func(value string) NullString {
+		return NullString{sql.NullString{String: value, Valid: true}}
+	}
+
+	tests := map[string]struct {
+		a, b          NullString
+		wantEqual     bool
+		wantEqualFold bool
+	}{
+		"invalid and invalid":         {a: NullString{}, b: NullString{sql.NullString{String: "stale"}}, wantEqual: true, wantEqualFold: true},
+		"invalid and valid":           {a: NullString{}, b: valid("")},
+		"valid and invalid":           {a: valid("hello"), b: NullString{}},
+		"same valid values":           {a: valid("hello"), b: valid("hello"), wantEqual: true, wantEqualFold: true},
+		"case-differing valid values": {a: valid("Hello"), b: valid("hELLO"), wantEqualFold: true},
+		"different valid values":      {a: valid("hello"), b: valid("world")},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEqual, tt.a.Equal(tt.b))
+			assert.Equal(t, tt.wantEqualFold, tt.a.EqualFold(tt.b))
+		})
+	}
+}
+
+func TestMapNullSlices(t *testing.T) {
+	t.Run("MapNullInts drops invalid values", func(t *testing.T) {
+		ns := []NullInt{
+			{sql.NullInt64{Int64: 1, Valid: true}},
+			{},
+			{sql.NullInt64{Int64: 0, Valid: true}},
+			{sql.NullInt64{Int64: 3, Valid: false}},
+			{sql.NullInt64{Int64: 42, Valid: true}},
+		}
+
+		assert.Equal(t, []int64{1, 0, 42}, MapNullInts(ns))
+		assert.Equal(t, []int64{}, MapNullInts(nil))
+	})
+
+	t.Run("MapNullStrings drops invalid values", func(t *testing.T) {
+		ns := []NullString{
+			{sql.NullString{String: "a", Valid: true}},
+			{},
+			{sql.NullString{String: "", Valid: true}},
+			{sql.NullString{String: "b", Valid: false}},
+		}
+
+		assert.Equal(t, []string{"a", ""}, MapNullStrings(ns))
+		assert.Equal(t, []string{}, MapNullStrings(nil))
+	})
+}
+
+func TestNullStringToLikePattern(t *testing.T) {
+	tests := map[string]struct {
+		value NullString
+		want  string
+	}{
+		"plain value is wrapped":     {value: NullString{sql.NullString{String: "foo", Valid: true}}, want: "%foo%"},
+		"percent is escaped":         {value: NullString{sql.NullString{String: "50%", Valid: true}}, want: `%50\%%`},
+		"underscore is escaped":      {value: NullString{sql.NullString{String: "a_b", Valid: true}}, want: `%a\_b%`},
+		"backslash is escaped":       {value: NullString{sql.NullString{String: `a\b`, Valid: true}}, want: `%a\\b%`},
+		"mixed wildcards":            {value: NullString{sql.NullString{String: `%_\`, Valid: true}}, want: `%\%\_\\%`},
+		"invalid returns empty":      {value: NullString{}, want: ""},
+		"empty string returns empty": {value: NullString{sql.NullString{String: "", Valid: true}}, want: ""},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.value.ToLikePattern())
+		})
+	}
+}
+
+func TestJSONNullOmission(t *testing.T) {
+	type dto struct {
+		Quantity *int64   `json:"quantity,omitempty"`
+		Price    *float64 `json:"price,omitempty"`
+	}
+
+	t.Run("invalid values are omitted from parent struct", func(t *testing.T) {
+		out, err := json.Marshal(dto{
+			Quantity: JSONNullInt64{}.MapToInt64(),
+			Price:    JSONNullFloat64{}.MapToFloat64(),
+		})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{}`, string(out))
+	})
+
+	t.Run("valid zero values are kept in parent struct", func(t *testing.T) {
+		out, err := json.Marshal(dto{
+			Quantity: JSONNullInt64{sql.NullInt64{Int64: 0, Valid: true}}.MapToInt64(),
+			Price:    JSONNullFloat64{sql.NullFloat64{Float64: 0, Valid: true}}.MapToFloat64(),
+		})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"quantity":0,"price":0}`, string(out))
+	})
+
+	t.Run("IsZero reports invalid values", func(t *testing.T) {
+		assert.True(t, JSONNullInt64{}.IsZero())
+		assert.False(t, JSONNullInt64{sql.NullInt64{Int64: 0, Valid: true}}.IsZero())
+		assert.True(t, JSONNullFloat64{}.IsZero())
+		assert.False(t, JSONNullFloat64{sql.NullFloat64{Float64: 0, Valid: true}}.IsZero())
+	})
+}
+
+func TestNullTimeScan(t *testing.T) {
+	paris := time.FixedZone("Paris", 3600)
+	tests := map[string]struct {
+		value   interface{}
+		want    NullTime
+		wantErr bool
+	}{
+		"nil is invalid": {
+			value: nil,
+			want:  NullTime{},
+		},
+		"UTC time is kept": {
+			value: time.Date(2023, 1, 2, 10, 30, 0, 0, time.UTC),
+			want:  NullTime{sql.NullTime{Time: time.Date(2023, 1, 2, 10, 30, 0, 0, time.UTC), Valid: true}},
+		},
+		"non UTC time is forced to UTC date": {
+			value: time.Date(2023, 1, 2, 10, 30, 0, 0, paris),
+			want:  validNullTime(2023, 1, 2),
+		},
+		"byte slice date": {
+			value: []byte("2023-01-02"),
+			want:  validNullTime(2023, 1, 2),
+		},
+		"string date": {
+			value: "2023-01-02",
+			want:  validNullTime(2023, 1, 2),
+		},
+		"RFC3339 byte slice": {
+			value: []byte("2023-01-02T10:30:00Z"),
+			want:  NullTime{sql.NullTime{Time: time.Date(2023, 1, 2, 10, 30, 0, 0, time.UTC), Valid: true}},
+		},
+		"RFC3339 byte slice with offset is forced to UTC date as a non UTC time": {
+			value: []byte("2023-01-02T23:30:00+02:00"),
+			want:  validNullTime(2023, 1, 2),
+		},
+		"malformed byte slice errors": {
+			value:   []byte("02/01/2023"),
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var nt NullTime
+			err := nt.Scan(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, nt.Valid)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Valid, nt.Valid)
+			assert.True(t, tt.want.Time.Equal(nt.Time), "expected %v, got %v", tt.want.Time, nt.Time)
+		})
+	}
+}
+
+func TestNullIntMapToInt(t *testing.T) {
+	t.Run("invalid returns nil", func(t *testing.T) {
+		value, err := NullInt{}.MapToInt()
+
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("platform boundaries are converted", func(t *testing.T) {
+		for _, boundary := range []int{math.MaxInt, math.MinInt} {
+			value, err := NullInt{sql.NullInt64{Int64: int64(boundary), Valid: true}}.MapToInt()
+
+			require.NoError(t, err)
+			require.NotNil(t, value)
+			assert.Equal(t, boundary, *value)
+		}
+	})
+
+	t.Run("values beyond platform boundaries return error", func(t *testing.T) {
+		if strconv.IntSize == 64 {
+			t.Skip("int64 values always fit in int on 64-bit platforms")
+		}
+		value, err := NullInt{sql.NullInt64{Int64: math.MaxInt32 + 1, Valid: true}}.MapToInt()
+
+		assert.Error(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestNullBoolStrict(t *testing.T) {
+	validFalse := NullBool{sql.NullBool{Bool: false, Valid: true}}
+	invalid := NullBool{}
+
+	t.Run("MapToBoolStrict", func(t *testing.T) {
+		value := validFalse.MapToBoolStrict()
+		require.NotNil(t, value)
+		assert.False(t, *value)
+
+		assert.Nil(t, invalid.MapToBoolStrict())
+	})
+
+	t.Run("ValueOr", func(t *testing.T) {
+		assert.False(t, validFalse.ValueOr(true))
+		assert.True(t, NullBool{sql.NullBool{Bool: true, Valid: true}}.ValueOr(false))
+		assert.True(t, invalid.ValueOr(true))
+		assert.False(t, invalid.ValueOr(false))
+	})
+
+	t.Run("IsEmpty does not distinguish valid false from invalid", func(t *testing.T) {
+		assert.True(t, validFalse.IsEmpty())
+		assert.True(t, invalid.IsEmpty())
+	})
+}
+
+func TestNullBoolMapToString(t *testing.T) {
+	yes := NullBool{sql.NullBool{Bool: true, Valid: true}}.MapToString("Yes", "No")
+	require.NotNil(t, yes)
+	assert.Equal(t, "Yes", *yes)
+
+	no := NullBool{sql.NullBool{Bool: false, Valid: true}}.MapToString("Yes", "No")
+	require.NotNil(t, no)
+	assert.Equal(t, "No", *no)
+
+	assert.Nil(t, NullBool{}.MapToString("Yes", "No"))
+}
+
+func TestNullBoolJSON(t *testing.T) {
+	tests := map[string]struct {
+		value NullBool
+		want  string
+	}{
+		"true":    {value: NullBool{sql.NullBool{Bool: true, Valid: true}}, want: "true"},
+		"false":   {value: NullBool{sql.NullBool{Bool: false, Valid: true}}, want: "false"},
+		"invalid": {value: NullBool{}, want: "null"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, []byte(tt.want), out)
+
+			var nb NullBool
+			require.NoError(t, json.Unmarshal(out, &nb))
+			assert.Equal(t, tt.value, nb)
+		})
+	}
+}
+
+func TestNullTimeIsFutureAndIsPast(t *testing.T) {
+	now := Now
+	t.Cleanup(func() { Now = now })
+	Now = func() time.Time {
+		return time.Date(2023, 1, 2, 12, 0, 0, 0, time.FixedZone("Paris", 3600))
+	}
+
+	tests := map[string]struct {
+		value      NullTime
+		wantFuture bool
+		wantPast   bool
+	}{
+		"future date": {value: validNullTime(2023, 1, 3), wantFuture: true},
+		"past date":   {value: validNullTime(2023, 1, 1), wantPast: true},
+		"same instant": {
+			value: NullTime{sql.NullTime{Time: time.Date(2023, 1, 2, 11, 0, 0, 0, time.UTC), Valid: true}},
+		},
+		"invalid": {value: NullTime{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.wantFuture, tt.value.IsFuture())
+			assert.Equal(t, tt.wantPast, tt.value.IsPast())
+		})
+	}
+}
+
+func TestNullTimeAge(t *testing.T) {
+	now := Now
+	t.Cleanup(func() { Now = now })
+	Now = func() time.Time {
+		return time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("past date", func(t *testing.T) {
+		age, ok := validNullTime(2023, 1, 1).Age()
+
+		assert.True(t, ok)
+		assert.Equal(t, 36*time.Hour, age)
+	})
+
+	t.Run("future date is negative", func(t *testing.T) {
+		age, ok := validNullTime(2023, 1, 3).Age()
+
+		assert.True(t, ok)
+		assert.Equal(t, -12*time.Hour, age)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		age, ok := NullTime{}.Age()
+
+		assert.False(t, ok)
+		assert.Zero(t, age)
+	})
+}
+
+func TestNullTimeTruncateToDay(t *testing.T) {
+	t.Run("mid-day time is truncated to midnight UTC", func(t *testing.T) {
+		nt := NullTime{sql.NullTime{Time: time.Date(2023, 1, 2, 15, 4, 5, 6, time.UTC), Valid: true}}
+
+		assert.Equal(t, validNullTime(2023, 1, 2), nt.TruncateToDay())
+	})
+
+	t.Run("date of non UTC time is preserved", func(t *testing.T) {
+		nt := NullTime{sql.NullTime{Time: time.Date(2023, 1, 2, 0, 30, 0, 0, time.FixedZone("Paris", 3600)), Valid: true}}
+
+		assert.Equal(t, validNullTime(2023, 1, 2), nt.TruncateToDay())
+	})
+
+	t.Run("invalid is passed through", func(t *testing.T) {
+		assert.Equal(t, NullTime{}, NullTime{}.TruncateToDay())
+	})
+}
+
+func TestNullTimeBucket(t *testing.T) {
+	valid := func(key string) NullString {
+		return NullString{sql.NullString{String: key, Valid: true}}
+	}
+
+	tests := []struct {
+		name string
+		nt   NullTime
+		unit BucketUnit
+		want NullString
+	}{
+		{name: "day", nt: validNullTime(2023, 1, 15), unit: BucketDay, want: valid("2023-01-15")},
+		{name: "week", nt: validNullTime(2023, 1, 18), unit: BucketWeek, want: valid("2023-W03")},
+		{name: "week belonging to previous ISO year", nt: validNullTime(2021, 1, 1), unit: BucketWeek, want: valid("2020-W53")},
+		{name: "month", nt: validNullTime(2023, 1, 15), unit: BucketMonth, want: valid("2023-01")},
+		{name: "year", nt: validNullTime(2023, 1, 15), unit: BucketYear, want: valid("2023")},
+		{name: "invalid time", nt: NullTime{}, unit: BucketDay, want: NullString{}},
+		{name: "unknown unit", nt: validNullTime(2023, 1, 15), unit: BucketUnit(42), want: NullString{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.nt.Bucket(tt.unit))
+		})
+	}
+}
+
+func TestNullTimeIn(t *testing.T) {
+	tokyo := time.FixedZone("UTC+9", 9*3600)
+
+	t.Run("valid time is converted to location", func(t *testing.T) {
+		nt := NullTime{sql.NullTime{Time: time.Date(2023, 1, 2, 20, 30, 0, 0, time.UTC), Valid: true}}
+
+		out := nt.In(tokyo)
+
+		assert.True(t, out.Valid)
+		assert.True(t, nt.Time.Equal(out.Time))
+		assert.Equal(t, tokyo, out.Time.Location())
+		value := nt.MapToStringIn(tokyo)
+		require.NotNil(t, value)
+		assert.Equal(t, "2023-01-03T05:30:00+09:00", *value)
+	})
+
+	t.Run("invalid time is passed through", func(t *testing.T) {
+		assert.Equal(t, NullTime{}, NullTime{}.In(tokyo))
+		assert.Nil(t, NullTime{}.MapToStringIn(tokyo))
+	})
+}
+
+func TestNullStringSlice(t *testing.T) {
+	t.Run("Scan", func(t *testing.T) {
+		tests := map[string]struct {
+			value interface{}
+			want  NullStringSlice
+		}{
+			"nil is invalid":              {value: nil, want: NullStringSlice{}},
+			"array literal":               {value: []byte("{a,b,c}"), want: NullStringSlice{Strings: []string{"a", "b", "c"}, Valid: true}},
+			"array literal with quotes":   {value: `{"c d","e\"f",g}`, want: NullStringSlice{Strings: []string{"c d", `e"f`, "g"}, Valid: true}},
+			"empty array literal":         {value: "{}", want: NullStringSlice{Strings: []string{}, Valid: true}},
+			"JSON array":                  {value: []byte(`["a","b"]`), want: NullStringSlice{Strings: []string{"a", "b"}, Valid: true}},
+			"empty JSON array":            {value: "[]", want: NullStringSlice{Strings: []string{}, Valid: true}},
+			"array literal quoted commas": {value: `{"a,b",c}`, want: NullStringSlice{Strings: []string{"a,b", "c"}, Valid: true}},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				var ns NullStringSlice
+				require.NoError(t, ns.Scan(tt.value))
+				assert.Equal(t, tt.want, ns)
+			})
+		}
+
+		for _, value := range []interface{}{"a,b", "{a,NULL}", `{"a}`, 42} {
+			var ns NullStringSlice
+			assert.Error(t, ns.Scan(value), "value %v", value)
+		}
+	})
+
+	t.Run("JSON distinguishes empty from null", func(t *testing.T) {
+		b, err := json.Marshal(NullStringSlice{Valid: true})
+		require.NoError(t, err)
+		assert.JSONEq(t, `[]`, string(b))
+
+		b, err = json.Marshal(NullStringSlice{})
+		require.NoError(t, err)
+		assert.Equal(t, "null", string(b))
+
+		var ns NullStringSlice
+		require.NoError(t, json.Unmarshal([]byte(`[]`), &ns))
+		assert.True(t, ns.Valid)
+		assert.True(t, ns.IsEmpty())
+
+		require.NoError(t, json.Unmarshal([]byte(`null`), &ns))
+		assert.False(t, ns.Valid)
+	})
+
+	t.Run("Value round-trips through Scan", func(t *testing.T) {
+		in := NullStringSlice{Strings: []string{"a", "c d", `e"f`, `g\h`}, Valid: true}
+		value, err := in.Value()
+		require.NoError(t, err)
+
+		var out NullStringSlice
+		require.NoError(t, out.Scan(value))
+		assert.Equal(t, in, out)
+
+		value, err = NullStringSlice{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
+
+func TestNullValueRoundTrip(t *testing.T) {
+	t.Run("NullInt keeps a valid zero", func(t *testing.T) {
+		value, err := NullInt{sql.NullInt64{Int64: 0, Valid: true}}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), value)
+
+		var ni NullInt
+		require.NoError(t, ni.Scan(value))
+		assert.Equal(t, NullInt{sql.NullInt64{Int64: 0, Valid: true}}, ni)
+	})
+
+	t.Run("NullFloat keeps a valid zero", func(t *testing.T) {
+		value, err := NullFloat{sql.NullFloat64{Float64: 0, Valid: true}}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, float64(0), value)
+
+		var nf NullFloat
+		require.NoError(t, nf.Scan(value))
+		assert.Equal(t, NullFloat{sql.NullFloat64{Float64: 0, Valid: true}}, nf)
+	})
+
+	t.Run("NullString keeps a valid empty string", func(t *testing.T) {
+		value, err := NullString{sql.NullString{String: "", Valid: true}}.Value()
+		require.NoError(t, err)
+		assert.Equal(t, "", value)
+
+		var ns NullString
+		require.NoError(t, ns.Scan(value))
+		assert.Equal(t, NullString{sql.NullString{String: "", Valid: true}}, ns)
+	})
+
+	t.Run("invalid values are written as NULL and read back invalid", func(t *testing.T) {
+		for _, valuer := range []driver.Valuer{NullInt{}, NullFloat{}, NullString{}} {
+			value, err := valuer.Value()
+			require.NoError(t, err)
+			assert.Nil(t, value, "%T", valuer)
+		}
+
+		ni := NullInt{sql.NullInt64{Int64: 3, Valid: true}}
+		require.NoError(t, ni.Scan(nil))
+		assert.False(t, ni.Valid)
+	})
+
+	t.Run("scanned zero values are marshalled as null, zero-keeping variants keep them", func(t *testing.T) {
+		var ni NullInt
+		require.NoError(t, ni.Scan(int64(0)))
+		var nf NullFloat
+		require.NoError(t, nf.Scan(float64(0)))
+		var ns NullString
+		require.NoError(t, ns.Scan(""))
+
+		out, err := json.Marshal([]interface{}{ni, nf, ns})
+		require.NoError(t, err)
+		assert.JSONEq(t, `[null,null,null]`, string(out))
+
+		assert.Equal(t, int64(0), ni.MapForRequestZero())
+		assert.Equal(t, float64(0), nf.MapForRequestZero())
+		assert.True(t, ns.Equal(NullString{sql.NullString{String: "", Valid: true}}))
+	})
+}
+
+func TestNullDateTime(t *testing.T) {
+	paris := time.FixedZone("Paris", 3600)
+	clock := time.Date(2023, 1, 2, 10, 30, 0, 0, time.UTC)
+
+	t.Run("JSON round-trips hours and minutes", func(t *testing.T) {
+		in := NullDateTime{sql.NullTime{Time: clock, Valid: true}}
+
+		out, err := json.Marshal(in)
+		require.NoError(t, err)
+		assert.Equal(t, `"2023-01-02T10:30:00Z"`, string(out))
+
+		var got NullDateTime
+		require.NoError(t, json.Unmarshal(out, &got))
+		assert.True(t, got.Valid)
+		assert.True(t, clock.Equal(got.Time), "expected %v, got %v", clock, got.Time)
+	})
+
+	t.Run("invalid is marshalled as null", func(t *testing.T) {
+		out, err := json.Marshal(NullDateTime{})
+		require.NoError(t, err)
+		assert.Equal(t, "null", string(out))
+	})
+
+	t.Run("Scan keeps clock of non UTC time", func(t *testing.T) {
+		var ndt NullDateTime
+		require.NoError(t, ndt.Scan(time.Date(2023, 1, 2, 11, 30, 0, 0, paris)))
+		assert.True(t, ndt.Valid)
+		assert.Equal(t, clock, ndt.Time)
+
+		require.NoError(t, ndt.Scan([]byte("2023-01-02T10:30:00Z")))
+		assert.Equal(t, clock, ndt.Time)
+
+		require.NoError(t, ndt.Scan(nil))
+		assert.False(t, ndt.Valid)
+	})
+
+	t.Run("conversions", func(t *testing.T) {
+		ndt := NullDateTime{sql.NullTime{Time: clock, Valid: true}}
+
+		assert.Equal(t, validNullTime(2023, 1, 2), ndt.ToDate())
+		assert.Equal(t, ndt, NullTime{sql.NullTime{Time: clock, Valid: true}}.ToDateTime())
+		assert.Equal(t, NullTime{}, NullDateTime{}.ToDate())
+		assert.Equal(t, NullDateTime{}, NullTime{}.ToDateTime())
+	})
+}
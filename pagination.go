@@ -1,49 +1,521 @@
 package pagination
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
-// Default returns a default pagination with offset 0 and limit DefaultLimit500
+// NewPagination builds pagination from raw offset and limit, e.g. for non-HTTP callers, with the same checks as GetFromURLQuery
+// A zero limit is replaced by the package default, use Unbounded for a pagination without limit
+// A zero offset is kept, so that the first page can always be requested whatever the package default offset
+// A limit higher than the package max limit is lowered to it, or rejected like in GetFromURLQuery
+// Returns a BadRequestValueError if offset or limit is invalid
+func NewPagination(offset, limit int) (Pagination, error) {
+	opts := DefaultOptions()
+	requested := limit != 0
+	if !requested {
+		limit = opts.DefaultLimit
+	}
+
+	offset, err := validateOffset(defaultQueryKeys.Offset, offset)
+	if err != nil {
+		return Pagination{}, err
+	}
+
+	limit, err = validateLimit(defaultQueryKeys.Limit, limit)
+	if err != nil {
+		return Pagination{}, err
+	}
+	return applyLimitPolicy(Pagination{Offset: offset, Limit: limit}, requested, defaultQueryKeys.Limit, opts)
+}
+
+// Default returns a default pagination with package default offset and limit (0 and DefaultLimit500 unless configured)
 func Default() Pagination {
 	return Pagination{
-		Offset: 0,
-		Limit:  DefaultLimit500,
+		Offset: CurrentDefaultOffset(),
+		Limit:  CurrentDefaultLimit(),
+	}
+}
+
+// Unbounded returns a pagination returning all rows, with offset 0 and limit 0
+func Unbounded() Pagination {
+	return Pagination{Offset: 0, Limit: 0}
+}
+
+// IsUnbounded returns true if pagination has no limit, i.e. limit is 0 and it is not an empty page
+func (p Pagination) IsUnbounded() bool {
+	return p.Limit == 0 && !p.EmptyPage
+}
+
+// SQLClause returns the LIMIT and OFFSET clause of pagination, LIMIT being omitted when unbounded and 0 for an empty page
+func (p Pagination) SQLClause() string {
+	if p.IsUnbounded() {
+		return fmt.Sprintf("OFFSET %d", p.Offset)
+	}
+	return fmt.Sprintf("LIMIT %d OFFSET %d", p.Limit, p.Offset)
+}
+
+// Split divides the [Offset, Offset+Limit) window of pagination into consecutive paginations of at most batchSize rows,
+// the last one being smaller if needed. A batchSize of 0 or less, or a limit of 0, gives pagination itself
+func (p Pagination) Split(batchSize int) []Pagination {
+	if batchSize <= 0 || p.Limit == 0 {
+		return []Pagination{p}
+	}
+
+	batches := make([]Pagination, 0, (p.Limit+batchSize-1)/batchSize)
+	for start := 0; start < p.Limit; start += batchSize {
+		batches = append(batches, Pagination{Offset: p.Offset + start, Limit: min(batchSize, p.Limit-start)})
+	}
+	return batches
+}
+
+// IsDeepOffset returns true if offset is above threshold, i.e. if OFFSET pagination is likely to be slow
+func (p Pagination) IsDeepOffset(threshold int) bool {
+	return p.Offset > threshold
+}
+
+// ValidateOffsetAgainstTotal returns an OffsetBeyondTotalError if offset of p is past the last of total elements,
+// for handlers answering 404 rather than an empty page. A zero or unknown total is never rejected
+func ValidateOffsetAgainstTotal(p Pagination, total int64) error {
+	if total > 0 && int64(p.Offset) >= total {
+		return OffsetBeyondTotalError{Offset: p.Offset, Total: total}
+	}
+	return nil
+}
+
+// SuggestKeyset returns true if a repository should switch from OFFSET to keyset pagination for p, i.e. if p is bounded
+// and its offset is above threshold. It is advisory only, an unbounded pagination reading all rows anyway
+func SuggestKeyset(p Pagination, threshold int) bool {
+	return !p.IsUnbounded() && p.IsDeepOffset(threshold)
+}
+
+// Clamp returns pagination forced into valid bounds, for paginations from untrusted sources which should be fixed rather than rejected
+// Negative offset becomes 0 and offset is capped at MaxOffset, negative or zero limit becomes the package default limit,
+// and limit is capped at maxLimit unless maxLimit is 0 or less
+func (p Pagination) Clamp(maxLimit int) Pagination {
+	offset := min(max(p.Offset, 0), MaxOffset)
+
+	limit := p.Limit
+	if limit <= 0 {
+		limit = CurrentDefaultLimit()
+	}
+	if maxLimit > 0 && limit > maxLimit {
+		limit = maxLimit
+	}
+	return Pagination{Offset: offset, Limit: limit}
+}
+
+// String returns a compact representation of pagination for logging, e.g. "offset=0 limit=100"
+func (p Pagination) String() string {
+	return fmt.Sprintf("offset=%d limit=%d", p.Offset, p.Limit)
+}
+
+// QueryKeys defines the names of the url query parameters holding offset and limit
+type QueryKeys struct {
+	Offset string
+	Limit  string
+}
+
+// defaultQueryKeys are the query parameter names used by GetFromURLQuery and GetFromRequest
+var defaultQueryKeys = QueryKeys{Offset: "offset", Limit: "limit"}
+
+// Options defines the pagination policy of an endpoint, see GetFromURLQueryWithOptions
+// A MaxLimit of 0 means no maximum, empty keys fall back to "offset" and "limit"
+// With a MaxLimit, an unbounded limit of 0 counts as higher than it
+// With RejectOverMax, requested limits higher than MaxLimit give a LimitTooLargeError instead of being lowered,
+// a default limit higher than MaxLimit being lowered to it in any case
+// ZeroLimit defines how an explicit limit=0 is handled, see ZeroLimitPolicy, before MaxLimit applies
+type Options struct {
+	DefaultLimit  int
+	MaxLimit      int
+	RejectOverMax bool
+	DefaultOffset int
+	OffsetKey     string
+	LimitKey      string
+	ZeroLimit     ZeroLimitPolicy
+}
+
+// ZeroLimitPolicy defines how a limit explicitly set to 0 in the query is handled
+type ZeroLimitPolicy int
+
+const (
+	// ZeroLimitUnbounded keeps limit=0, giving an unbounded pagination returning all rows, see Pagination.IsUnbounded
+	// It is the default policy, matching previous versions. With a max limit, the limit is lowered to it like any higher limit
+	ZeroLimitUnbounded ZeroLimitPolicy = iota
+	// ZeroLimitDefault replaces limit=0 with the default limit
+	ZeroLimitDefault
+	// ZeroLimitReject rejects limit=0 with a BadRequestValueError
+	ZeroLimitReject
+	// ZeroLimitEmpty gives an empty page returning no rows, see Pagination.EmptyPage, e.g. to only read the total
+	ZeroLimitEmpty
+)
+
+// DefaultOptions returns options with package default offset, limit, maximum limit and zero limit policy, and default keys
+func DefaultOptions() Options {
+	return Options{
+		DefaultLimit:  CurrentDefaultLimit(),
+		MaxLimit:      CurrentMaxLimit(),
+		DefaultOffset: CurrentDefaultOffset(),
+		OffsetKey:     defaultQueryKeys.Offset,
+		LimitKey:      defaultQueryKeys.Limit,
+		ZeroLimit:     CurrentZeroLimitPolicy(),
 	}
 }
 
 // GetFromURLQuery gets page (limit and offset) from url query
 func GetFromURLQuery(c *gin.Context) (Pagination, error) {
-	key := "offset"
-	offset, err := strconv.Atoi(c.DefaultQuery(key, strconv.Itoa(DefaultOffset)))
+	return GetFromURLQueryWithOptions(c, DefaultOptions())
+}
+
+// MustGetFromURLQuery gets page (limit and offset) from url query, panics if it cannot be parsed
+// Only use it for internal or admin endpoints with trusted callers, where a parse error is a programming bug
+func MustGetFromURLQuery(c *gin.Context) Pagination {
+	page, err := GetFromURLQuery(c)
+	if err != nil {
+		panic(fmt.Sprintf("pagination: %v", err))
+	}
+	return page
+}
+
+// GetFromURLQueryWithOptions gets page (limit and offset) from url query using opts
+// Requested limits higher than opts.MaxLimit, unbounded ones included, are lowered to it,
+// or rejected with a LimitTooLargeError if opts.RejectOverMax is set
+func GetFromURLQueryWithOptions(c *gin.Context, opts Options) (Pagination, error) {
+	return getFromQueryWithOptions(c.Request.URL.Query(), opts)
+}
+
+// getFromQueryWithOptions parses and validates offset and limit from query using opts
+func getFromQueryWithOptions(query url.Values, opts Options) (Pagination, error) {
+	keys := QueryKeys{Offset: opts.OffsetKey, Limit: opts.LimitKey}
+	if keys.Offset == "" {
+		keys.Offset = defaultQueryKeys.Offset
+	}
+	if keys.Limit == "" {
+		keys.Limit = defaultQueryKeys.Limit
+	}
+
+	defaults := Pagination{Offset: opts.DefaultOffset, Limit: opts.DefaultLimit}
+	page, err := getFromQuery(query, keys, defaults)
+	if err != nil {
+		return Pagination{}, err
+	}
+
+	page, err = applyLimitPolicy(page, query.Has(keys.Limit), keys.Limit, opts)
+	if err != nil {
+		return Pagination{}, err
+	}
+
+	if OnParse != nil {
+		OnParse(page)
+	}
+	return page, nil
+}
+
+// applyLimitPolicy applies the zero limit policy and max limit of opts to the limit of page, stored under key
+// Only a limit requested by the client is rejected over max, a defaulted one being lowered to it
+func applyLimitPolicy(page Pagination, requested bool, key string, opts Options) (Pagination, error) {
+	if requested && page.Limit == 0 {
+		switch opts.ZeroLimit {
+		case ZeroLimitDefault:
+			page.Limit, requested = opts.DefaultLimit, false
+		case ZeroLimitReject:
+			return Pagination{}, BadRequestValueError{Key: key, Err: fmt.Errorf("%s cannot be 0", key)}
+		case ZeroLimitEmpty:
+			page.EmptyPage = true
+		}
+	}
+
+	if opts.MaxLimit > 0 && (page.IsUnbounded() || page.Limit > opts.MaxLimit) {
+		if opts.RejectOverMax && requested {
+			return Pagination{}, LimitTooLargeError{Requested: page.Limit, Max: opts.MaxLimit}
+		}
+		page.Limit = opts.MaxLimit
+	}
+	return page, nil
+}
+
+// GetFromURLQueryWithKeys gets page (limit and offset) from url query using custom parameter names,
+// with the same defaults, max limit and zero limit policy as GetFromURLQuery
+func GetFromURLQueryWithKeys(c *gin.Context, keys QueryKeys) (Pagination, error) {
+	opts := DefaultOptions()
+	opts.OffsetKey, opts.LimitKey = keys.Offset, keys.Limit
+	return getFromQueryWithOptions(c.Request.URL.Query(), opts)
+}
+
+// GetFromURLQueryAll gets page (limit and offset) from url query, validating both
+// Returns a MultiError holding all failures when several parameters are invalid
+// The package max limit and zero limit policy apply as in GetFromURLQuery
+func GetFromURLQueryAll(c *gin.Context) (Pagination, error) {
+	opts := DefaultOptions()
+	query := c.Request.URL.Query()
+	page, errs := parseQuery(query, defaultQueryKeys, Pagination{Offset: opts.DefaultOffset, Limit: opts.DefaultLimit})
+	if len(errs) > 0 {
+		return Pagination{}, MultiError{Errors: errs}
+	}
+
+	page, err := applyLimitPolicy(page, query.Has(defaultQueryKeys.Limit), defaultQueryKeys.Limit, opts)
+	if err != nil {
+		return Pagination{}, MultiError{Errors: []error{err}}
+	}
+
+	if OnParse != nil {
+		OnParse(page)
+	}
+	return page, nil
+}
+
+// GetFromJSONBody gets page (limit and offset) from the "offset" and "limit" fields of a JSON request body,
+// with the same defaults and validation as GetFromURLQuery. Absent or null fields get default values
+// The body is restored so that the handler can read it again
+func GetFromJSONBody(c *gin.Context) (Pagination, error) {
+	var body []byte
+	if c.Request.Body != nil {
+		var err error
+		body, err = io.ReadAll(c.Request.Body)
+		if err != nil {
+			return Pagination{}, BadRequestValueError{Key: "body", Err: err}
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	var fields map[string]json.RawMessage
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return Pagination{}, BadRequestValueError{Key: "body", Err: err}
+		}
+	}
+
+	query := url.Values{}
+	for _, key := range []string{defaultQueryKeys.Offset, defaultQueryKeys.Limit} {
+		if value, ok := fields[key]; ok && string(value) != "null" {
+			query.Set(key, string(value))
+		}
+	}
+	return getFromQueryWithOptions(query, DefaultOptions())
+}
+
+// Header names read by GetFromHeaders
+const (
+	HeaderOffset = "X-Offset"
+	HeaderLimit  = "X-Limit"
+)
+
+// GetFromHeaders gets page (limit and offset) from HeaderOffset and HeaderLimit request headers,
+// with the same defaults and validation as GetFromURLQuery, errors referencing header names
+func GetFromHeaders(c *gin.Context) (Pagination, error) {
+	opts := DefaultOptions()
+	opts.OffsetKey, opts.LimitKey = HeaderOffset, HeaderLimit
+
+	query := url.Values{}
+	for _, key := range []string{HeaderOffset, HeaderLimit} {
+		if values := c.Request.Header.Values(key); len(values) > 0 {
+			query.Set(key, values[0])
+		}
+	}
+	return getFromQueryWithOptions(query, opts)
+}
+
+// HasPaginationParams returns true if offset or limit is present in url query, even with an empty value
+// It lets handlers tell a requested pagination apart from a defaulted one
+func HasPaginationParams(c *gin.Context) bool {
+	query := c.Request.URL.Query()
+	return query.Has(defaultQueryKeys.Offset) || query.Has(defaultQueryKeys.Limit)
+}
+
+// GetFromRequest gets page (limit and offset) from the url query of a standard http request
+func GetFromRequest(r *http.Request) (Pagination, error) {
+	return getFromQueryWithOptions(r.URL.Query(), DefaultOptions())
+}
+
+// OnParse, when not nil, is called with every pagination successfully parsed from url query, e.g. for logging
+// It receives the pagination once validated and clamped to the max limit, and is never called on parse errors
+var OnParse func(Pagination)
+
+// getFromQuery parses and validates offset and limit from query using keys, defaults being used for absent keys
+// Only the first error is returned
+func getFromQuery(query url.Values, keys QueryKeys, defaults Pagination) (Pagination, error) {
+	page, errs := parseQuery(query, keys, defaults)
+	if len(errs) > 0 {
+		return Pagination{}, errs[0]
+	}
+	return page, nil
+}
+
+// parseQuery parses and validates offset and limit from query using keys, returning all errors
+func parseQuery(query url.Values, keys QueryKeys, defaults Pagination) (Pagination, []error) {
+	var errs []error
+	offset, err := parseOffset(keys.Offset, queryValue(query, keys.Offset, strconv.Itoa(defaults.Offset)))
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	limit, err := parseLimit(keys.Limit, queryValue(query, keys.Limit, strconv.Itoa(defaults.Limit)))
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return Pagination{Offset: offset, Limit: limit}, errs
+}
+
+// parseOffset parses and validates offset value of key
+func parseOffset(key, value string) (int, error) {
+	offset, err := strconv.Atoi(value)
 	if err != nil {
-		return Pagination{}, BadRequestValueError{Key: key, Err: err}
+		return 0, BadRequestValueError{Key: key, Err: err}
 	}
+	return validateOffset(key, offset)
+}
 
+// validateOffset validates offset value of key
+func validateOffset(key string, offset int) (int, error) {
 	if offset < 0 {
-		return Pagination{}, BadRequestValueError{Key: key, Err: fmt.Errorf("offset (%d) cannot be negative", offset)}
+		return 0, BadRequestValueError{Key: key, Err: fmt.Errorf("%s (%d) cannot be negative", key, offset)}
 	}
 
-	key = "limit"
-	limit, err := strconv.Atoi(c.DefaultQuery(key, strconv.Itoa(DefaultLimit500)))
+	if offset > MaxOffset {
+		return 0, BadRequestValueError{Key: key, Err: fmt.Errorf("%s (%d) cannot exceed %d", key, offset, MaxOffset)}
+	}
+	return offset, nil
+}
+
+// parseLimit parses and validates limit value of key
+func parseLimit(key, value string) (int, error) {
+	limit, err := strconv.Atoi(value)
 	if err != nil {
-		return Pagination{}, BadRequestValueError{Key: key, Err: err}
+		return 0, BadRequestValueError{Key: key, Err: err}
 	}
+	return validateLimit(key, limit)
+}
 
+// validateLimit validates limit value of key
+func validateLimit(key string, limit int) (int, error) {
 	if limit < 0 {
-		return Pagination{}, BadRequestValueError{Key: key, Err: fmt.Errorf("limit (%d) cannot be negative", limit)}
+		return 0, BadRequestValueError{Key: key, Err: fmt.Errorf("%s (%d) cannot be negative", key, limit)}
+	}
+	return limit, nil
+}
+
+// queryValue returns the value of key in query, or defaultValue if key is not present
+func queryValue(query url.Values, key, defaultValue string) string {
+	if values, ok := query[key]; ok && len(values) > 0 {
+		return values[0]
+	}
+	return defaultValue
+}
+
+// ToQueryString renders pagination as a url query string, e.g. "offset=0&limit=100"
+func (p Pagination) ToQueryString() string {
+	return p.ToQueryStringWithKeys(defaultQueryKeys)
+}
+
+// ToQueryStringWithKeys renders pagination as a url query string using custom parameter names
+func (p Pagination) ToQueryStringWithKeys(keys QueryKeys) string {
+	return fmt.Sprintf("%s=%d&%s=%d", url.QueryEscape(keys.Offset), p.Offset, url.QueryEscape(keys.Limit), p.Limit)
+}
+
+// AppendToURL sets offset and limit in the query of base, other query parameters being preserved
+func (p Pagination) AppendToURL(base string) (string, error) {
+	return p.AppendToURLWithKeys(base, defaultQueryKeys)
+}
+
+// AppendToURLWithKeys sets offset and limit in the query of base using custom parameter names,
+// other query parameters being preserved
+func (p Pagination) AppendToURLWithKeys(base string, keys QueryKeys) (string, error) {
+	u, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("could not parse url %q: %w", base, err)
+	}
+
+	query := u.Query()
+	query.Set(keys.Offset, strconv.Itoa(p.Offset))
+	query.Set(keys.Limit, strconv.Itoa(p.Limit))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// FromPage builds pagination from a 1-based page number and a page size
+// Returns a BadRequestValueError if the resulting offset would exceed MaxOffset
+func FromPage(page, perPage int) (Pagination, error) {
+	if page < 1 {
+		return Pagination{}, BadRequestValueError{Key: "page", Err: fmt.Errorf("page (%d) must be at least 1", page)}
+	}
+
+	if perPage < 0 {
+		return Pagination{}, BadRequestValueError{Key: "per_page", Err: fmt.Errorf("per_page (%d) cannot be negative", perPage)}
+	}
+
+	if perPage > 0 && page-1 > MaxOffset/perPage {
+		return Pagination{}, BadRequestValueError{Key: "page", Err: fmt.Errorf("page (%d) is too high, offset cannot exceed %d", page, MaxOffset)}
+	}
+
+	return Pagination{Offset: PageToOffset(page, perPage), Limit: perPage}, nil
+}
+
+// OffsetToPage returns the 1-based page number holding offset for pages of limit rows
+// A limit of 0 or less gives page 1, a negative offset being treated as 0
+func OffsetToPage(offset, limit int) int {
+	if limit <= 0 || offset <= 0 {
+		return 1
 	}
+	return offset/limit + 1
+}
 
-	return Pagination{Offset: offset, Limit: limit}, nil
+// PageToOffset returns the offset of the first row of the 1-based page for pages of perPage rows
+// A page lower than 1 is treated as 1 and a perPage of 0 or less gives offset 0
+func PageToOffset(page, perPage int) int {
+	if page <= 1 || perPage <= 0 {
+		return 0
+	}
+	return (page - 1) * perPage
+}
+
+// LastPage returns a pagination pointing at the start of the last page for total items, keeping the same limit
+// Offset is 0 when total fits in a single page or when limit is 0
+func (p Pagination) LastPage(total int64) Pagination {
+	if p.Limit <= 0 || total <= int64(p.Limit) {
+		return Pagination{Offset: 0, Limit: p.Limit}
+	}
+
+	limit := int64(p.Limit)
+	return Pagination{Offset: int((total - 1) / limit * limit), Limit: p.Limit}
+}
+
+// Next returns the pagination of the page following p
+func (p Pagination) Next() Pagination {
+	return Pagination{Offset: p.Offset + p.Limit, Limit: p.Limit}
+}
+
+// Previous returns the pagination of the page preceding p, offset never being negative
+// When a full previous page does not exist, limit is shrunk so that the window stops where p starts,
+// and on the first page p itself is returned
+func (p Pagination) Previous() Pagination {
+	if p.Offset <= 0 {
+		return Pagination{Offset: 0, Limit: p.Limit}
+	}
+
+	if p.Offset < p.Limit {
+		return Pagination{Offset: 0, Limit: p.Offset}
+	}
+	return Pagination{Offset: p.Offset - p.Limit, Limit: p.Limit}
 }
 
 // BuildPageable builds pageable from entity
+// A nil data slice is replaced by an empty one so that data is always marshalled as an array
 func BuildPageable[T any](page Pagination, total int64, data []T) Pageable {
+	if data == nil {
+		data = []T{}
+	}
+
 	return Pageable{
 		Limit:  page.Limit,
 		Offset: page.Offset,
@@ -52,8 +524,358 @@ func BuildPageable[T any](page Pagination, total int64, data []T) Pageable {
 	}
 }
 
+// BuildPageableSlicing builds pageable from the full result set, applying page window to allData
+// Total is the length of allData, an offset beyond it gives empty data and a limit of 0 keeps all remaining rows
+func BuildPageableSlicing[T any](page Pagination, allData []T) Pageable {
+	start := min(max(page.Offset, 0), len(allData))
+	end := len(allData)
+	if !page.IsUnbounded() {
+		end = min(start+max(page.Limit, 0), len(allData))
+	}
+
+	return BuildPageable(page, int64(len(allData)), allData[start:end])
+}
+
+// BuildPageableConcurrent runs countFn and dataFn concurrently and builds pageable from their results
+// Returns the first error, the context given to both functions being cancelled as soon as one fails
+func BuildPageableConcurrent[T any](
+	ctx context.Context,
+	page Pagination,
+	countFn func(context.Context) (int64, error),
+	dataFn func(context.Context, Pagination) ([]T, error),
+) (Pageable, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var total int64
+	var data []T
+	errs := make(chan error, 2)
+	go func() {
+		var err error
+		total, err = countFn(ctx)
+		errs <- err
+	}()
+	go func() {
+		var err error
+		data, err = dataFn(ctx, page)
+		errs <- err
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if err != nil {
+				return Pageable{}, err
+			}
+		case <-ctx.Done():
+			return Pageable{}, ctx.Err()
+		}
+	}
+
+	return BuildPageable(page, total, data), nil
+}
+
+// BuildEmptyPageable builds pageable holding only total, data being an empty slice marshalled as []
+func BuildEmptyPageable(page Pagination, total int64) Pageable {
+	return BuildPageable(page, total, []interface{}{})
+}
+
+// EmptyPageable returns a pageable holding no rows, data being an empty slice marshalled as []
+func EmptyPageable() Pageable {
+	return Pageable{Total: 0, Data: []interface{}{}}
+}
+
+// IsEmpty returns true if pageable holds no rows, i.e. total is 0
+func (p Pageable) IsEmpty() bool {
+	return p.Total == 0
+}
+
+// HasNext returns true if rows follow the page held by pageable
+// When total is TotalUnknown, a full page is assumed to be followed by more rows
+func (p Pageable) HasNext() bool {
+	length := p.dataLen()
+	if p.Total == TotalUnknown {
+		return p.Limit > 0 && length == p.Limit
+	}
+	return int64(p.Offset+length) < p.Total
+}
+
+// Remaining returns the number of rows following the page held by pageable, never negative
+// Remaining is 0 when total is TotalUnknown
+func (p Pageable) Remaining() int64 {
+	return max(0, p.Total-(int64(p.Offset)+int64(p.dataLen())))
+}
+
+// TotalPages returns the number of pages of limit rows needed to hold total rows, TotalUnknown if total is unknown
+// A limit of 0 gives a single page unless total is 0
+func (p Pageable) TotalPages() int {
+	switch {
+	case p.Total == TotalUnknown:
+		return TotalUnknown
+	case p.Total <= 0:
+		return 0
+	case p.Limit <= 0:
+		return 1
+	}
+	return int((p.Total + int64(p.Limit) - 1) / int64(p.Limit))
+}
+
+// String returns a compact representation of pageable for logging, data contents being left out
+// e.g. "offset=0 limit=100 total=42 len(data)=42"
+func (p Pageable) String() string {
+	return fmt.Sprintf("offset=%d limit=%d total=%d len(data)=%d", p.Offset, p.Limit, p.Total, p.dataLen())
+}
+
+// dataLen returns the number of elements of pageable data, 0 if it is not a slice
+func (p Pageable) dataLen() int {
+	value := reflect.ValueOf(p.Data)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return 0
+	}
+	return value.Len()
+}
+
+// BuildPageableOf builds typed pageable from entity
+// A nil data slice is replaced by an empty one so that data is always marshalled as an array
+func BuildPageableOf[T any](page Pagination, total int64, data []T) PageableOf[T] {
+	if data == nil {
+		data = []T{}
+	}
+
+	return PageableOf[T]{
+		Limit:  page.Limit,
+		Offset: page.Offset,
+		Total:  total,
+		Data:   data,
+	}
+}
+
+// BuildPageableEstimate builds pageable holding an estimated total, exact telling whether estimate is an exact count
+// A nil data slice is replaced by an empty one so that data is always marshalled as an array
+func BuildPageableEstimate[T any](page Pagination, estimate int64, exact bool, data []T) PageableEstimate {
+	if data == nil {
+		data = []T{}
+	}
+
+	return PageableEstimate{
+		Limit:          page.Limit,
+		Offset:         page.Offset,
+		EstimatedTotal: estimate,
+		TotalIsExact:   exact,
+		Data:           data,
+	}
+}
+
+// BuildPageableWithLinks builds pageable with links to the pages of baseURL, built with Pagination.AppendToURL
+// Prev is nil on the first page and Next on the last one, both being nil when page is unbounded
+// All links are nil if baseURL cannot be parsed
+func BuildPageableWithLinks[T any](page Pagination, total int64, data []T, baseURL string) PageableWithLinks {
+	pageable := BuildPageable(page, total, data)
+	return PageableWithLinks{
+		Limit:  pageable.Limit,
+		Offset: pageable.Offset,
+		Total:  pageable.Total,
+		Data:   pageable.Data,
+		Links:  buildLinks(page, total, baseURL),
+	}
+}
+
+// buildLinks builds navigation links of page among total rows
+func buildLinks(page Pagination, total int64, baseURL string) PageableLinks {
+	link := func(p Pagination) *string {
+		value, err := p.AppendToURL(baseURL)
+		if err != nil {
+			return nil
+		}
+		return &value
+	}
+
+	links := PageableLinks{
+		Self:  link(page),
+		First: link(Pagination{Offset: 0, Limit: page.Limit}),
+		Last:  link(page.LastPage(total)),
+	}
+	if links.Self == nil || page.Limit == 0 {
+		return links
+	}
+
+	if page.Offset > 0 {
+		links.Prev = link(page.Previous())
+	}
+	if int64(page.Offset+page.Limit) < total {
+		links.Next = link(page.Next())
+	}
+	return links
+}
+
+// ToUntyped converts typed pageable to Pageable
+func (p PageableOf[T]) ToUntyped() Pageable {
+	return Pageable{
+		Limit:  p.Limit,
+		Offset: p.Offset,
+		Total:  p.Total,
+		Data:   p.Data,
+	}
+}
+
+// FromPageable converts Pageable to typed pageable, casting data with PageableToSlice
+func FromPageable[T any](p Pageable) (PageableOf[T], error) {
+	data, err := PageableToSlice[T](p)
+	if err != nil {
+		return PageableOf[T]{}, err
+	}
+
+	return PageableOf[T]{
+		Limit:  p.Limit,
+		Offset: p.Offset,
+		Total:  p.Total,
+		Data:   data,
+	}, nil
+}
+
+// WithData returns a copy of pageable with data replaced, limit, offset and total being preserved
+func (p Pageable) WithData(data interface{}) Pageable {
+	p.Data = data
+	return p
+}
+
+// Slim converts pageable to a SlimPageable, which omits zero valued pagination fields when marshalled
+func (p Pageable) Slim() SlimPageable {
+	return SlimPageable{
+		Limit:  p.Limit,
+		Offset: p.Offset,
+		Total:  p.Total,
+		Data:   p.Data,
+	}
+}
+
+// Format defines how Pageable is marshalled, see ResponseFormat
+type Format int
+
+const (
+	// FormatFlat marshals limit, offset and total next to data: {"limit":10,"offset":0,"total":42,"data":[]}
+	FormatFlat Format = iota
+	// FormatMeta marshals limit, offset and total in a meta object: {"meta":{"limit":10,"offset":0,"total":42},"data":[]}
+	FormatMeta
+)
+
+// ResponseFormat is the format used by Pageable.MarshalJSON, FormatFlat unless configured
+// It is meant to be configured once at startup and is not safe for concurrent modification
+var ResponseFormat = FormatFlat
+
+// pageableMeta holds pagination fields of Pageable, total being omitted when unknown
+type pageableMeta struct {
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	Total  *int64 `json:"total,omitempty"`
+}
+
+// DataFieldName is the JSON key of data used by Pageable.MarshalJSON, "data" unless configured
+// It is meant to be configured once at startup and is not safe for concurrent modification
+var DataFieldName = "data"
+
+// MarshalJSON marshals Pageable according to ResponseFormat, data under DataFieldName and total being omitted when it is TotalUnknown
+func (p Pageable) MarshalJSON() ([]byte, error) {
+	meta := pageableMeta{Limit: p.Limit, Offset: p.Offset}
+	if p.Total != TotalUnknown {
+		total := p.Total
+		meta.Total = &total
+	}
+
+	var head interface{} = meta
+	if ResponseFormat == FormatMeta {
+		head = struct {
+			Meta pageableMeta `json:"meta"`
+		}{Meta: meta}
+	}
+
+	out, err := json.Marshal(head)
+	if err != nil {
+		return nil, err
+	}
+	key, err := json.Marshal(DataFieldName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(applyMarshalHook(p.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	// head is never an empty object, data is appended as its last field
+	out = append(out[:len(out)-1], ',')
+	out = append(out, key...)
+	out = append(out, ':')
+	out = append(out, data...)
+	return append(out, '}'), nil
+}
+
+// UnmarshalJSON unmarshals Pageable marshalled by MarshalJSON, pagination fields being read at top level or
+// in a meta object whatever ResponseFormat, and data under DataFieldName. An absent total is read as TotalUnknown
+func (p *Pageable) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+
+	rawMeta, ok := fields["meta"]
+	if !ok {
+		rawMeta = b
+	}
+	var meta pageableMeta
+	if err := json.Unmarshal(rawMeta, &meta); err != nil {
+		return fmt.Errorf("unable to decode pagination fields of pageable: %w", err)
+	}
+
+	var data interface{}
+	if raw, ok := fields[DataFieldName]; ok {
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return fmt.Errorf("unable to decode %s field of pageable: %w", DataFieldName, err)
+		}
+	}
+
+	p.Limit, p.Offset, p.Total, p.Data = meta.Limit, meta.Offset, TotalUnknown, data
+	if meta.Total != nil {
+		p.Total = *meta.Total
+	}
+	return nil
+}
+
+// MarshalHook, when not nil, is called by Pageable.MarshalJSON on every data element, the returned value being marshalled
+// in its place, e.g. to restructure elements for an API version. Data that is not a slice is marshalled as is
+// It is meant to be configured once at startup and is not safe for concurrent modification
+var MarshalHook func(interface{}) interface{}
+
+// applyMarshalHook returns data with MarshalHook applied to each of its elements, data itself if there is no hook
+func applyMarshalHook(data interface{}) interface{} {
+	if MarshalHook == nil {
+		return data
+	}
+
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice || value.IsNil() {
+		return data
+	}
+
+	hooked := make([]interface{}, value.Len())
+	for i := range hooked {
+		hooked[i] = MarshalHook(value.Index(i).Interface())
+	}
+	return hooked
+}
+
+// MarshalJSON marshals typed pageable the same way as Pageable, according to ResponseFormat
+func (p PageableOf[T]) MarshalJSON() ([]byte, error) {
+	return p.ToUntyped().MarshalJSON()
+}
+
 // PageableToSlice casts Data interface field (from json Unmarshalling of Pageable) to slice of type T
+// Data already holding a slice of type T (from BuildPageable) is returned as is
 func PageableToSlice[T any](pageable Pageable) ([]T, error) {
+	if data, ok := pageable.Data.([]T); ok {
+		return data, nil
+	}
+
 	sliceInterface, ok := pageable.Data.([]interface{})
 	if !ok {
 		return []T{}, errors.New("unable to cast data field of pageable to a slice of interface")
@@ -74,3 +896,229 @@ func PageableToSlice[T any](pageable Pageable) ([]T, error) {
 	}
 	return data, nil
 }
+
+// PageableToMap casts Data interface field to a map of type T elements keyed by keyFn, decoding data with PageableToSlice
+// Returns an error if two elements have the same key
+func PageableToMap[K comparable, T any](p Pageable, keyFn func(T) K) (map[K]T, error) {
+	data, err := PageableToSlice[T](p)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[K]T, len(data))
+	for _, value := range data {
+		key := keyFn(value)
+		if _, ok := values[key]; ok {
+			return nil, fmt.Errorf("duplicate key %v in data field of pageable", key)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// MergePageable merges two pageables of the same type, as returned by different shards for the same query
+// Data are concatenated, totals and limits are summed and the smallest offset is kept
+func MergePageable[T any](a, b Pageable) (Pageable, error) {
+	dataA, err := PageableToSlice[T](a)
+	if err != nil {
+		return Pageable{}, err
+	}
+
+	dataB, err := PageableToSlice[T](b)
+	if err != nil {
+		return Pageable{}, err
+	}
+
+	data := make([]T, 0, len(dataA)+len(dataB))
+	data = append(data, dataA...)
+	data = append(data, dataB...)
+
+	return Pageable{
+		Limit:  a.Limit + b.Limit,
+		Offset: min(a.Offset, b.Offset),
+		Total:  a.Total + b.Total,
+		Data:   data,
+	}, nil
+}
+
+// MapPageable applies fn to each element of pageable data, keeping limit, offset and total
+func MapPageable[In any, Out any](p Pageable, fn func(In) Out) (Pageable, error) {
+	data, err := PageableToSlice[In](p)
+	if err != nil {
+		return Pageable{}, err
+	}
+
+	mapped := make([]Out, 0, len(data))
+	for _, value := range data {
+		mapped = append(mapped, fn(value))
+	}
+
+	return p.WithData(mapped), nil
+}
+
+// FilterPageable keeps the elements of pageable data for which keep returns true, keeping limit and offset
+// Total is set to the number of kept elements, i.e. it counts the filtered page rather than the whole result set
+func FilterPageable[T any](p Pageable, keep func(T) bool) (Pageable, error) {
+	data, err := PageableToSlice[T](p)
+	if err != nil {
+		return Pageable{}, err
+	}
+
+	filtered := make([]T, 0, len(data))
+	for _, value := range data {
+		if keep(value) {
+			filtered = append(filtered, value)
+		}
+	}
+
+	filteredPageable := p.WithData(filtered)
+	filteredPageable.Total = int64(len(filtered))
+	return filteredPageable, nil
+}
+
+// ApplyFieldSelection keeps only the top-level keys listed in fields in each element of pageable data, unknown fields being ignored
+// Elements, typed ones included, are decoded to map[string]interface{} with PageableToSlice, an empty fields keeping pageable as is
+func ApplyFieldSelection(p Pageable, fields []string) (Pageable, error) {
+	if len(fields) == 0 {
+		return p, nil
+	}
+
+	data, err := PageableToSlice[map[string]interface{}](p.WithData(toInterfaceSlice(p.Data)))
+	if err != nil {
+		return Pageable{}, err
+	}
+
+	selected := make([]map[string]interface{}, 0, len(data))
+	for _, element := range data {
+		kept := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			if value, ok := element[field]; ok {
+				kept[field] = value
+			}
+		}
+		selected = append(selected, kept)
+	}
+
+	return p.WithData(selected), nil
+}
+
+// toInterfaceSlice returns the elements of a typed slice, e.g. from BuildPageable, as a []interface{}, other data as is
+func toInterfaceSlice(data interface{}) interface{} {
+	if _, ok := data.([]interface{}); ok {
+		return data
+	}
+
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return data
+	}
+
+	elements := make([]interface{}, value.Len())
+	for i := range elements {
+		elements[i] = value.Index(i).Interface()
+	}
+	return elements
+}
+
+// PageableDelta describes changes to apply to a cached pageable, see ApplyDelta
+// Total is the total of the pageable once the changes are applied
+type PageableDelta struct {
+	Added   []interface{}
+	Removed []interface{}
+	Total   int64
+}
+
+// ApplyDelta removes the elements of delta.Removed from base data and appends those of delta.Added, elements being matched by key
+// An added element whose key is already in base data replaces it in place, and total is set to delta.Total
+// Elements are decoded with PageableToSlice
+func ApplyDelta[T any, K comparable](base Pageable, delta PageableDelta, key func(T) K) (Pageable, error) {
+	data, err := PageableToSlice[T](base)
+	if err != nil {
+		return Pageable{}, err
+	}
+
+	added, err := PageableToSlice[T](Pageable{Data: delta.Added})
+	if err != nil {
+		return Pageable{}, err
+	}
+
+	removed, err := PageableToSlice[T](Pageable{Data: delta.Removed})
+	if err != nil {
+		return Pageable{}, err
+	}
+
+	removedKeys := make(map[K]struct{}, len(removed))
+	for _, value := range removed {
+		removedKeys[key(value)] = struct{}{}
+	}
+
+	result := make([]T, 0, len(data)+len(added))
+	positions := make(map[K]int, len(data))
+	for _, value := range data {
+		k := key(value)
+		if _, ok := removedKeys[k]; ok {
+			continue
+		}
+		positions[k] = len(result)
+		result = append(result, value)
+	}
+
+	for _, value := range added {
+		k := key(value)
+		if position, ok := positions[k]; ok {
+			result[position] = value
+			continue
+		}
+		positions[k] = len(result)
+		result = append(result, value)
+	}
+
+	return Pageable{
+		Limit:  base.Limit,
+		Offset: base.Offset,
+		Total:  delta.Total,
+		Data:   result,
+	}, nil
+}
+
+// CollectAll fetches pages starting at start until all rows are collected according to Total, decoding data with PageableToSlice
+// When Total is TotalUnknown, pages are fetched until one is not full
+// Returns an error if a page makes no progress, i.e. if rows left to collect do not decrease, so that a misbehaving fetch cannot loop forever
+func CollectAll[T any](ctx context.Context, fetch func(p Pagination) (Pageable, error), start Pagination) ([]T, error) {
+	all := []T{}
+	page := start
+	remaining := int64(-1)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pageable, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := PageableToSlice[T](pageable)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, data...)
+
+		page.Offset += len(data)
+		if pageable.Total == TotalUnknown {
+			if len(data) == 0 || !pageable.HasNext() {
+				return all, nil
+			}
+			continue
+		}
+
+		left := pageable.Total - int64(page.Offset)
+		if left <= 0 {
+			return all, nil
+		}
+		if len(data) == 0 || (remaining >= 0 && left >= remaining) {
+			return nil, fmt.Errorf("collecting pages made no progress at offset %d, %d rows left out of %d", page.Offset, left, pageable.Total)
+		}
+		remaining = left
+	}
+}
@@ -2,11 +2,17 @@ package pagination
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -41,6 +47,16 @@ func TestGetFromURLQuery(t *testing.T) {
 			limit:   "-2",
 			wantErr: true,
 		},
+		"when offset exceeds MaxOffset, returns error": {
+			offset:  strconv.FormatInt(MaxOffset+1, 10),
+			limit:   "100",
+			wantErr: true,
+		},
+		"when offset overflows int, returns error": {
+			offset:  "99999999999999999999999",
+			limit:   "100",
+			wantErr: true,
+		},
 		"nominal": {
 			offset: "0",
 			limit:  "100",
@@ -73,6 +89,507 @@ func TestGetFromURLQuery(t *testing.T) {
 	}
 }
 
+func TestGetFromRequest(t *testing.T) {
+	t.Run("when params are absent, returns defaults", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		page, err := GetFromRequest(r)
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: DefaultOffset, Limit: DefaultLimit500}, page)
+	})
+
+	t.Run("when param is present but empty, returns error", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?limit=", nil)
+
+		_, err := GetFromRequest(r)
+
+		assert.ErrorAs(t, err, &BadRequestValueError{})
+	})
+
+	t.Run("nominal", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/?offset=20&limit=10", nil)
+
+		page, err := GetFromRequest(r)
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 20, Limit: 10}, page)
+	})
+}
+
+func TestGetFromURLQueryAll(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		want     Pagination
+		wantKeys []string
+	}{
+		"when both params are invalid, returns both errors": {
+			url:      "/?offset=-1&limit=pouet",
+			wantKeys: []string{"offset", "limit"},
+		},
+		"when only limit is invalid, returns its error": {
+			url:      "/?offset=10&limit=-1",
+			wantKeys: []string{"limit"},
+		},
+		"nominal": {
+			url:  "/?offset=10&limit=20",
+			want: Pagination{Offset: 10, Limit: 20},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			page, err := GetFromURLQueryAll(c)
+
+			assert.Equal(t, tt.want, page)
+			if len(tt.wantKeys) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			var multiErr MultiError
+			require.ErrorAs(t, err, &multiErr)
+			require.Len(t, multiErr.Errors, len(tt.wantKeys))
+			for i, key := range tt.wantKeys {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, multiErr.Errors[i], &valueErr)
+				assert.Equal(t, key, valueErr.Key)
+				assert.Contains(t, err.Error(), key)
+			}
+			assert.ErrorAs(t, err, &BadRequestValueError{})
+			assert.Equal(t, http.StatusBadRequest, HTTPStatus(err))
+		})
+	}
+}
+
+func TestGetFromJSONBody(t *testing.T) {
+	tests := map[string]struct {
+		body       string
+		want       Pagination
+		wantErrKey string
+	}{
+		"nominal": {
+			body: `{"offset":20,"limit":10,"filters":{"label":"foo"}}`,
+			want: Pagination{Offset: 20, Limit: 10},
+		},
+		"when fields are absent, returns defaults": {
+			body: `{"filters":{}}`,
+			want: Pagination{Offset: DefaultOffset, Limit: DefaultLimit500},
+		},
+		"when fields are null, returns defaults": {
+			body: `{"offset":null,"limit":null}`,
+			want: Pagination{Offset: DefaultOffset, Limit: DefaultLimit500},
+		},
+		"when body is empty, returns defaults": {
+			body: ``,
+			want: Pagination{Offset: DefaultOffset, Limit: DefaultLimit500},
+		},
+		"when offset is negative, returns error": {
+			body:       `{"offset":-1,"limit":10}`,
+			wantErrKey: "offset",
+		},
+		"when limit is not an integer, returns error": {
+			body:       `{"offset":0,"limit":"ten"}`,
+			wantErrKey: "limit",
+		},
+		"when limit is a float, returns error": {
+			body:       `{"offset":0,"limit":1.5}`,
+			wantErrKey: "limit",
+		},
+		"when body is not JSON, returns error": {
+			body:       `offset=0`,
+			wantErrKey: "body",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			page, err := GetFromJSONBody(c)
+
+			assert.Equal(t, tt.want, page)
+			if tt.wantErrKey != "" {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, err, &valueErr)
+				assert.Equal(t, tt.wantErrKey, valueErr.Key)
+				return
+			}
+			require.NoError(t, err)
+
+			rest, err := io.ReadAll(c.Request.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.body, string(rest))
+		})
+	}
+}
+
+func TestGetFromHeaders(t *testing.T) {
+	tests := map[string]struct {
+		headers    map[string]string
+		want       Pagination
+		wantErrKey string
+	}{
+		"nominal": {
+			headers: map[string]string{"X-Offset": "20", "X-Limit": "10"},
+			want:    Pagination{Offset: 20, Limit: 10},
+		},
+		"when headers are missing, returns defaults": {
+			want: Pagination{Offset: DefaultOffset, Limit: DefaultLimit500},
+		},
+		"when only limit is set, defaults offset": {
+			headers: map[string]string{"x-limit": "10"},
+			want:    Pagination{Offset: DefaultOffset, Limit: 10},
+		},
+		"when limit is malformed, returns error": {
+			headers:    map[string]string{"X-Limit": "pouet"},
+			wantErrKey: HeaderLimit,
+		},
+		"when offset is negative, returns error": {
+			headers:    map[string]string{"X-Offset": "-1"},
+			wantErrKey: HeaderOffset,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/?offset=30&limit=30", nil)
+			for key, value := range tt.headers {
+				c.Request.Header.Set(key, value)
+			}
+
+			page, err := GetFromHeaders(c)
+
+			assert.Equal(t, tt.want, page)
+			if tt.wantErrKey != "" {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, err, &valueErr)
+				assert.Equal(t, tt.wantErrKey, valueErr.Key)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestHasPaginationParams(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want bool
+	}{
+		"when no param is present, returns false":       {url: "/?sort=name", want: false},
+		"when only offset is present, returns true":     {url: "/?offset=10", want: true},
+		"when only limit is present, returns true":      {url: "/?limit=10", want: true},
+		"when limit is present but empty, returns true": {url: "/?limit=", want: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			assert.Equal(t, tt.want, HasPaginationParams(c))
+		})
+	}
+}
+
+func TestOnParse(t *testing.T) {
+	var parsed []Pagination
+	t.Cleanup(func() { OnParse = nil })
+	OnParse = func(page Pagination) {
+		parsed = append(parsed, page)
+	}
+
+	t.Run("when parsing succeeds, callback receives pagination", func(t *testing.T) {
+		parsed = nil
+
+		page, err := GetFromRequest(httptest.NewRequest(http.MethodGet, "/?offset=20&limit=10", nil))
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pagination{page}, parsed)
+	})
+
+	t.Run("when parsing fails, callback is not called", func(t *testing.T) {
+		parsed = nil
+
+		_, err := GetFromRequest(httptest.NewRequest(http.MethodGet, "/?offset=-20", nil))
+
+		require.Error(t, err)
+		assert.Empty(t, parsed)
+	})
+
+	t.Run("when limit is rejected over max, callback is not called", func(t *testing.T) {
+		parsed = nil
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?limit=1000", nil)
+
+		_, err := GetFromURLQueryWithOptions(c, Options{DefaultLimit: 20, MaxLimit: 100, RejectOverMax: true})
+
+		require.ErrorAs(t, err, &LimitTooLargeError{})
+		assert.Empty(t, parsed)
+	})
+
+	t.Run("when limit is clamped, callback receives clamped limit", func(t *testing.T) {
+		parsed = nil
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?limit=1000", nil)
+
+		_, err := GetFromURLQueryWithOptions(c, Options{DefaultLimit: 20, MaxLimit: 100})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pagination{{Offset: 0, Limit: 100}}, parsed)
+	})
+
+	t.Run("when limit=0 is rejected, callback is not called", func(t *testing.T) {
+		parsed = nil
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?limit=0", nil)
+
+		_, err := GetFromURLQueryWithOptions(c, Options{DefaultLimit: 20, ZeroLimit: ZeroLimitReject})
+
+		require.Error(t, err)
+		assert.Empty(t, parsed)
+	})
+
+	t.Run("when limit=0 is replaced by default, callback receives default limit", func(t *testing.T) {
+		parsed = nil
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?limit=0", nil)
+
+		_, err := GetFromURLQueryWithOptions(c, Options{DefaultLimit: 20, ZeroLimit: ZeroLimitDefault})
+
+		require.NoError(t, err)
+		assert.Equal(t, []Pagination{{Offset: 0, Limit: 20}}, parsed)
+	})
+
+	t.Run("Middleware calls callback once with clamped limit", func(t *testing.T) {
+		parsed = nil
+		api := gin.New()
+		api.Use(Middleware(WithMaxLimit(50)))
+		api.GET("/", func(*gin.Context) {})
+
+		api.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?limit=1000", nil))
+
+		assert.Equal(t, []Pagination{{Offset: 0, Limit: 50}}, parsed)
+	})
+}
+
+func TestGetFromURLQueryWithKeys(t *testing.T) {
+	keys := QueryKeys{Offset: "skip", Limit: "take"}
+	tests := map[string]struct {
+		url     string
+		want    Pagination
+		wantErr string
+	}{
+		"when custom offset is negative, returns error referencing custom key": {
+			url:     "/?skip=-1&take=10",
+			wantErr: "skip",
+		},
+		"when custom limit cannot be converted to int, returns error referencing custom key": {
+			url:     "/?skip=0&take=pouet",
+			wantErr: "take",
+		},
+		"when default keys are used, they are ignored": {
+			url:  "/?offset=20&limit=10",
+			want: Pagination{Offset: DefaultOffset, Limit: DefaultLimit500},
+		},
+		"nominal": {
+			url:  "/?skip=20&take=10",
+			want: Pagination{Offset: 20, Limit: 10},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var page Pagination
+			var err error
+			api := gin.Default()
+			api.GET("/", func(context *gin.Context) {
+				page, err = GetFromURLQueryWithKeys(context, keys)
+			})
+
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			api.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Equal(t, tt.want, page)
+			if tt.wantErr != "" {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, err, &valueErr)
+				assert.Equal(t, tt.wantErr, valueErr.Key)
+				assert.Contains(t, err.Error(), tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMustGetFromURLQuery(t *testing.T) {
+	t.Run("returns pagination on good input", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?offset=20&limit=10", nil)
+
+		assert.Equal(t, Pagination{Offset: 20, Limit: 10}, MustGetFromURLQuery(c))
+	})
+
+	t.Run("panics on bad input", func(t *testing.T) {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/?offset=-1", nil)
+
+		assert.Panics(t, func() { MustGetFromURLQuery(c) })
+	})
+}
+
+func TestGetFromURLQueryWithOptions(t *testing.T) {
+	custom := Options{DefaultLimit: 20, MaxLimit: 100, DefaultOffset: 5, OffsetKey: "skip", LimitKey: "take"}
+	tests := map[string]struct {
+		opts         Options
+		url          string
+		want         Pagination
+		wantErr      string
+		wantTooLarge *LimitTooLargeError
+	}{
+		"when params are absent, returns custom defaults": {
+			opts: custom,
+			url:  "/",
+			want: Pagination{Offset: 5, Limit: 20},
+		},
+		"when limit exceeds max, clamps it": {
+			opts: custom,
+			url:  "/?skip=10&take=1000",
+			want: Pagination{Offset: 10, Limit: 100},
+		},
+		"when custom limit is invalid, returns error referencing custom key": {
+			opts:    custom,
+			url:     "/?take=-1",
+			wantErr: "take",
+		},
+		"when default keys are used with custom keys, they are ignored": {
+			opts: custom,
+			url:  "/?offset=10&limit=10",
+			want: Pagination{Offset: 5, Limit: 20},
+		},
+		"when keys are empty, falls back to default keys": {
+			opts: Options{DefaultLimit: 20},
+			url:  "/?offset=10&limit=1000",
+			want: Pagination{Offset: 10, Limit: 1000},
+		},
+		"when limit exceeds max with RejectOverMax, returns LimitTooLargeError": {
+			opts:         Options{DefaultLimit: 20, MaxLimit: 100, RejectOverMax: true},
+			url:          "/?limit=1000",
+			wantTooLarge: &LimitTooLargeError{Requested: 1000, Max: 100},
+		},
+		"when limit is unbounded with a max, clamps it": {
+			opts: Options{DefaultLimit: 20, MaxLimit: 100},
+			url:  "/?limit=0",
+			want: Pagination{Offset: 0, Limit: 100},
+		},
+		"when default limit is unbounded with a max, clamps it": {
+			opts: Options{MaxLimit: 100},
+			url:  "/",
+			want: Pagination{Offset: 0, Limit: 100},
+		},
+		"when limit is unbounded with RejectOverMax, returns LimitTooLargeError": {
+			opts:         Options{DefaultLimit: 20, MaxLimit: 100, RejectOverMax: true},
+			url:          "/?limit=0",
+			wantTooLarge: &LimitTooLargeError{Requested: 0, Max: 100},
+		},
+		"when default limit exceeds max with RejectOverMax, clamps it": {
+			opts: Options{DefaultLimit: 500, MaxLimit: 100, RejectOverMax: true},
+			url:  "/",
+			want: Pagination{Offset: 0, Limit: 100},
+		},
+		"when default limit is unbounded with RejectOverMax, clamps it": {
+			opts: Options{MaxLimit: 100, RejectOverMax: true},
+			url:  "/",
+			want: Pagination{Offset: 0, Limit: 100},
+		},
+		"when limit=0 is replaced by a default over max with RejectOverMax, clamps it": {
+			opts: Options{DefaultLimit: 500, MaxLimit: 100, RejectOverMax: true, ZeroLimit: ZeroLimitDefault},
+			url:  "/?limit=0",
+			want: Pagination{Offset: 0, Limit: 100},
+		},
+		"when limit equals max with RejectOverMax, keeps it": {
+			opts: Options{DefaultLimit: 20, MaxLimit: 100, RejectOverMax: true},
+			url:  "/?limit=100",
+			want: Pagination{Offset: 0, Limit: 100},
+		},
+		"when limit is 0 with ZeroLimitUnbounded, keeps it": {
+			opts: Options{DefaultLimit: 20, ZeroLimit: ZeroLimitUnbounded},
+			url:  "/?limit=0",
+			want: Pagination{Offset: 0, Limit: 0},
+		},
+		"when limit is 0 with ZeroLimitDefault, uses default limit": {
+			opts: Options{DefaultLimit: 20, ZeroLimit: ZeroLimitDefault},
+			url:  "/?limit=0",
+			want: Pagination{Offset: 0, Limit: 20},
+		},
+		"when limit is 0 with ZeroLimitDefault, default limit is clamped to max": {
+			opts: Options{DefaultLimit: 200, MaxLimit: 100, ZeroLimit: ZeroLimitDefault},
+			url:  "/?limit=0",
+			want: Pagination{Offset: 0, Limit: 100},
+		},
+		"when limit is 0 with ZeroLimitUnbounded and a max, clamps it": {
+			opts: Options{DefaultLimit: 20, MaxLimit: 100, ZeroLimit: ZeroLimitUnbounded},
+			url:  "/?limit=0",
+			want: Pagination{Offset: 0, Limit: 100},
+		},
+		"when limit is 0 with ZeroLimitEmpty, returns an empty page": {
+			opts: Options{DefaultLimit: 20, ZeroLimit: ZeroLimitEmpty},
+			url:  "/?offset=10&limit=0",
+			want: Pagination{Offset: 10, Limit: 0, EmptyPage: true},
+		},
+		"when limit is 0 with ZeroLimitEmpty and a max, keeps the empty page": {
+			opts: Options{DefaultLimit: 20, MaxLimit: 100, ZeroLimit: ZeroLimitEmpty},
+			url:  "/?limit=0",
+			want: Pagination{Offset: 0, Limit: 0, EmptyPage: true},
+		},
+		"when limit is 0 with ZeroLimitReject, returns error": {
+			opts:    Options{DefaultLimit: 20, ZeroLimit: ZeroLimitReject},
+			url:     "/?limit=0",
+			wantErr: "limit",
+		},
+		"when limit is absent with ZeroLimitReject and zero default, keeps it": {
+			opts: Options{ZeroLimit: ZeroLimitReject},
+			url:  "/",
+			want: Pagination{Offset: 0, Limit: 0},
+		},
+		"when using default options, limit=0 is unbounded": {
+			opts: DefaultOptions(),
+			url:  "/?limit=0",
+			want: Unbounded(),
+		},
+		"when using default options, behaves as GetFromURLQuery": {
+			opts: DefaultOptions(),
+			url:  "/?offset=10",
+			want: Pagination{Offset: 10, Limit: DefaultLimit500},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			page, err := GetFromURLQueryWithOptions(c, tt.opts)
+
+			assert.Equal(t, tt.want, page)
+			if tt.wantTooLarge != nil {
+				var limitErr LimitTooLargeError
+				require.ErrorAs(t, err, &limitErr)
+				assert.Equal(t, *tt.wantTooLarge, limitErr)
+				assert.Contains(t, err.Error(), "exceeds max limit (100)")
+				assert.Equal(t, http.StatusBadRequest, HTTPStatus(err))
+			} else if tt.wantErr != "" {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, err, &valueErr)
+				assert.Equal(t, tt.wantErr, valueErr.Key)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestPageableToLabelSlice(t *testing.T) {
 	t.Run("When data is not a slice, returns empty slice and error", func(t *testing.T) {
 		sampleData := Pageable{}
@@ -107,7 +624,78 @@ func TestPageableToLabelSlice(t *testing.T) {
 		assert.Equal(t, "my second label", data[1].Label.String)
 
 	})
+
+	t.Run("When data is already a slice of Labels, returns no error and the slice", func(t *testing.T) {
+		labels := []Label{{Label: NullEmptyString{sql.NullString{String: "my label", Valid: true}}}}
+		data, err := PageableToSlice[Label](BuildPageable(Pagination{}, 1, labels))
+
+		assert.NoError(t, err)
+		assert.Equal(t, labels, data)
+	})
+}
+func TestNewPagination(t *testing.T) {
+	tests := map[string]struct {
+		offset, limit int
+		want          Pagination
+		wantErrKey    string
+	}{
+		"valid values are kept":                     {offset: 20, limit: 10, want: Pagination{Offset: 20, Limit: 10}},
+		"zero limit is defaulted, zero offset kept": {offset: 0, limit: 0, want: Pagination{Offset: 0, Limit: DefaultLimit500}},
+		"zero limit is defaulted":                   {offset: 20, limit: 0, want: Pagination{Offset: 20, Limit: DefaultLimit500}},
+		"negative offset errors":                    {offset: -1, limit: 10, wantErrKey: "offset"},
+		"negative limit errors":                     {offset: 20, limit: -1, wantErrKey: "limit"},
+		"offset over maximum error":                 {offset: math.MaxInt, limit: 10, wantErrKey: "offset"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			page, err := NewPagination(tt.offset, tt.limit)
+
+			assert.Equal(t, tt.want, page)
+			if tt.wantErrKey == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var valueErr BadRequestValueError
+			require.ErrorAs(t, err, &valueErr)
+			assert.Equal(t, tt.wantErrKey, valueErr.Key)
+		})
+	}
+
+	t.Run("explicit zero offset is kept with a default offset", func(t *testing.T) {
+		restoreDefaults(t)
+		require.NoError(t, SetDefaultOffset(20))
+
+		page, err := NewPagination(0, 10)
+
+		require.NoError(t, err)
+		assert.Equal(t, Pagination{Offset: 0, Limit: 10}, page)
+	})
+}
+
+func TestPaginationJSON(t *testing.T) {
+	out, err := json.Marshal(Pagination{Offset: 20, Limit: 10})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"offset":20,"limit":10}`, string(out))
+
+	var page Pagination
+	require.NoError(t, json.Unmarshal([]byte(`{"offset":20,"limit":10}`), &page))
+	assert.Equal(t, Pagination{Offset: 20, Limit: 10}, page)
+
+	t.Run("empty page survives a round trip", func(t *testing.T) {
+		empty := Pagination{Offset: 20, Limit: 0, EmptyPage: true}
+
+		out, err := json.Marshal(empty)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"offset":20,"limit":0,"empty_page":true}`, string(out))
+
+		var got Pagination
+		require.NoError(t, json.Unmarshal(out, &got))
+		assert.Equal(t, empty, got)
+		assert.False(t, got.IsUnbounded())
+		assert.Equal(t, "LIMIT 0 OFFSET 20", got.SQLClause())
+	})
 }
+
 func TestDefaultPagination(t *testing.T) {
 	t.Run("nominal", func(t *testing.T) {
 
@@ -121,6 +709,53 @@ func TestDefaultPagination(t *testing.T) {
 		assert.Equal(t, expect.Offset, out.Offset)
 	})
 }
+func TestUnbounded(t *testing.T) {
+	tests := map[string]struct {
+		page          Pagination
+		wantUnbounded bool
+		wantClause    string
+	}{
+		"unbounded": {
+			page:          Unbounded(),
+			wantUnbounded: true,
+			wantClause:    "OFFSET 0",
+		},
+		"unbounded with offset": {
+			page:          Pagination{Offset: 20, Limit: 0},
+			wantUnbounded: true,
+			wantClause:    "OFFSET 20",
+		},
+		"default": {
+			page:          Default(),
+			wantUnbounded: false,
+			wantClause:    "LIMIT 500 OFFSET 0",
+		},
+		"with limit of 1": {
+			page:          Pagination{Offset: 20, Limit: 1},
+			wantUnbounded: false,
+			wantClause:    "LIMIT 1 OFFSET 20",
+		},
+		"empty page": {
+			page:          Pagination{Offset: 20, Limit: 0, EmptyPage: true},
+			wantUnbounded: false,
+			wantClause:    "LIMIT 0 OFFSET 20",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.wantUnbounded, tt.page.IsUnbounded())
+			assert.Equal(t, tt.wantClause, tt.page.SQLClause())
+		})
+	}
+
+	t.Run("limit=0 in url query is unbounded", func(t *testing.T) {
+		page, err := GetFromRequest(httptest.NewRequest(http.MethodGet, "/?limit=0", nil))
+
+		require.NoError(t, err)
+		assert.True(t, page.IsUnbounded())
+	})
+}
+
 func TestBuildPageable(t *testing.T) {
 	t.Run("nominal label", func(t *testing.T) {
 		data := []Label{
@@ -177,4 +812,1183 @@ func TestBuildPageable(t *testing.T) {
 		assert.Equal(t, expect.Total, out.Total)
 		assert.Equal(t, expect.Offset, out.Offset)
 	})
+
+	t.Run("nil data is marshalled as empty array", func(t *testing.T) {
+		out, err := json.Marshal(BuildPageable[Label](Pagination{}, 0, nil))
+
+		require.NoError(t, err)
+		assert.Contains(t, string(out), `"data":[]`)
+	})
+
+	t.Run("nil typed data is marshalled as empty array", func(t *testing.T) {
+		out, err := json.Marshal(BuildPageableOf[Label](Pagination{}, 0, nil))
+
+		require.NoError(t, err)
+		assert.Contains(t, string(out), `"data":[]`)
+	})
+}
+
+func TestNextAndPrevious(t *testing.T) {
+	tests := map[string]struct {
+		page         Pagination
+		wantNext     Pagination
+		wantPrevious Pagination
+	}{
+		"in the middle of dataset": {
+			page:         Pagination{Offset: 20, Limit: 10},
+			wantNext:     Pagination{Offset: 30, Limit: 10},
+			wantPrevious: Pagination{Offset: 10, Limit: 10},
+		},
+		"when offset is exactly one page, previous is first page": {
+			page:         Pagination{Offset: 10, Limit: 10},
+			wantNext:     Pagination{Offset: 20, Limit: 10},
+			wantPrevious: Pagination{Offset: 0, Limit: 10},
+		},
+		"when a full previous page does not exist, previous limit is shrunk": {
+			page:         Pagination{Offset: 4, Limit: 10},
+			wantNext:     Pagination{Offset: 14, Limit: 10},
+			wantPrevious: Pagination{Offset: 0, Limit: 4},
+		},
+		"when on first page, previous is first page": {
+			page:         Pagination{Offset: 0, Limit: 10},
+			wantNext:     Pagination{Offset: 10, Limit: 10},
+			wantPrevious: Pagination{Offset: 0, Limit: 10},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.wantNext, tt.page.Next())
+			assert.Equal(t, tt.wantPrevious, tt.page.Previous())
+		})
+	}
+}
+
+func TestToQueryString(t *testing.T) {
+	page := Pagination{Offset: 0, Limit: 100}
+
+	assert.Equal(t, "offset=0&limit=100", page.ToQueryString())
+	assert.Equal(t, "skip=0&take=100", page.ToQueryStringWithKeys(QueryKeys{Offset: "skip", Limit: "take"}))
+}
+
+func TestAppendToURL(t *testing.T) {
+	page := Pagination{Offset: 20, Limit: 10}
+	tests := map[string]struct {
+		base    string
+		want    string
+		wantErr bool
+	}{
+		"when base has no query, adds pagination": {
+			base: "https://api.example.com/items",
+			want: "https://api.example.com/items?limit=10&offset=20",
+		},
+		"when base has other params, preserves them": {
+			base: "https://api.example.com/items?sort=name&q=foo",
+			want: "https://api.example.com/items?limit=10&offset=20&q=foo&sort=name",
+		},
+		"when base already has pagination, replaces it": {
+			base: "/items?offset=0&limit=500&sort=name",
+			want: "/items?limit=10&offset=20&sort=name",
+		},
+		"when base cannot be parsed, returns error": {
+			base:    "://items",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := page.AppendToURL(tt.base)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("with custom keys", func(t *testing.T) {
+		got, err := page.AppendToURLWithKeys("/items?sort=name", QueryKeys{Offset: "skip", Limit: "take"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "/items?skip=20&sort=name&take=10", got)
+	})
+}
+
+func TestFromPage(t *testing.T) {
+	tests := map[string]struct {
+		page    int
+		perPage int
+		want    Pagination
+		wantErr bool
+	}{
+		"when page is 0, returns error": {
+			page:    0,
+			perPage: 10,
+			wantErr: true,
+		},
+		"when per page is negative, returns error": {
+			page:    1,
+			perPage: -1,
+			wantErr: true,
+		},
+		"when page is MaxInt, returns error": {
+			page:    math.MaxInt,
+			perPage: 10,
+			wantErr: true,
+		},
+		"when offset would exceed MaxOffset, returns error": {
+			page:    MaxOffset/100 + 2,
+			perPage: 100,
+			wantErr: true,
+		},
+		"when per page is 0, returns offset 0": {
+			page:    math.MaxInt,
+			perPage: 0,
+			want:    Pagination{Offset: 0, Limit: 0},
+		},
+		"nominal": {
+			page:    3,
+			perPage: 20,
+			want:    Pagination{Offset: 40, Limit: 20},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			page, err := FromPage(tt.page, tt.perPage)
+			if tt.wantErr {
+				assert.ErrorAs(t, err, &BadRequestValueError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func TestOffsetToPage(t *testing.T) {
+	tests := map[string]struct {
+		offset, limit int
+		want          int
+	}{
+		"zero limit gives first page":      {offset: 40, limit: 0, want: 1},
+		"negative limit gives first page":  {offset: 40, limit: -10, want: 1},
+		"zero offset gives first page":     {offset: 0, limit: 10, want: 1},
+		"negative offset gives first page": {offset: -5, limit: 10, want: 1},
+		"offset within first page":         {offset: 9, limit: 10, want: 1},
+		"offset on page boundary":          {offset: 10, limit: 10, want: 2},
+		"offset within a later page":       {offset: 45, limit: 10, want: 5},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OffsetToPage(tt.offset, tt.limit))
+		})
+	}
+}
+
+func TestPageToOffset(t *testing.T) {
+	tests := map[string]struct {
+		page, perPage int
+		want          int
+	}{
+		"zero per page gives offset 0":     {page: 3, perPage: 0, want: 0},
+		"negative per page gives offset 0": {page: 3, perPage: -10, want: 0},
+		"first page gives offset 0":        {page: 1, perPage: 10, want: 0},
+		"zero page is the first page":      {page: 0, perPage: 10, want: 0},
+		"negative page is the first page":  {page: -2, perPage: 10, want: 0},
+		"second page":                      {page: 2, perPage: 10, want: 10},
+		"later page":                       {page: 5, perPage: 25, want: 100},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PageToOffset(tt.page, tt.perPage))
+		})
+	}
+
+	t.Run("round-trips with OffsetToPage on boundaries", func(t *testing.T) {
+		for page := 1; page <= 5; page++ {
+			assert.Equal(t, page, OffsetToPage(PageToOffset(page, 10), 10))
+		}
+	})
+}
+
+func TestLastPage(t *testing.T) {
+	tests := map[string]struct {
+		page  Pagination
+		total int64
+		want  Pagination
+	}{
+		"when total is an exact multiple of limit, returns start of last full page": {
+			page:  Pagination{Offset: 0, Limit: 10},
+			total: 30,
+			want:  Pagination{Offset: 20, Limit: 10},
+		},
+		"when last page is partial, returns start of partial page": {
+			page:  Pagination{Offset: 10, Limit: 10},
+			total: 35,
+			want:  Pagination{Offset: 30, Limit: 10},
+		},
+		"when total is less than limit, returns offset 0": {
+			page:  Pagination{Offset: 40, Limit: 50},
+			total: 12,
+			want:  Pagination{Offset: 0, Limit: 50},
+		},
+		"when total is 0, returns offset 0": {
+			page:  Pagination{Offset: 40, Limit: 50},
+			total: 0,
+			want:  Pagination{Offset: 0, Limit: 50},
+		},
+		"when limit is 0, returns offset 0": {
+			page:  Pagination{Offset: 40, Limit: 0},
+			total: 100,
+			want:  Pagination{Offset: 0, Limit: 0},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.page.LastPage(tt.total))
+		})
+	}
+}
+
+func TestPageableToMap(t *testing.T) {
+	key := func(label Label) string {
+		return label.Label.String
+	}
+
+	t.Run("When data is not castable, returns error", func(t *testing.T) {
+		_, err := PageableToMap(Pageable{Data: int64(1)}, key)
+		assert.Error(t, err)
+	})
+
+	t.Run("unique keys", func(t *testing.T) {
+		out, err := PageableToMap(MockPageableLabel("first", "second"), key)
+
+		require.NoError(t, err)
+		require.Len(t, out, 2)
+		assert.Equal(t, "first", out["first"].Label.String)
+		assert.Equal(t, "second", out["second"].Label.String)
+	})
+
+	t.Run("duplicate keys return error", func(t *testing.T) {
+		_, err := PageableToMap(MockPageableLabel("first", "second", "first"), key)
+
+		assert.ErrorContains(t, err, "first")
+	})
+}
+
+func TestMergePageable(t *testing.T) {
+	t.Run("When data is not castable, returns error", func(t *testing.T) {
+		_, err := MergePageable[Label](MockPageableLabel("my label"), Pageable{Data: int64(1)})
+		assert.Error(t, err)
+	})
+
+	t.Run("nominal", func(t *testing.T) {
+		a := MockPageableLabel("a1", "a2")
+		a.Offset, a.Limit, a.Total = 10, 2, 20
+		b := MockPageableLabel("b1")
+		b.Offset, b.Limit, b.Total = 5, 2, 15
+
+		out, err := MergePageable[Label](a, b)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(35), out.Total)
+		assert.Equal(t, 5, out.Offset)
+		assert.Equal(t, 4, out.Limit)
+		data, err := PageableToSlice[Label](out)
+		require.NoError(t, err)
+		require.Len(t, data, 3)
+		assert.Equal(t, "a1", data[0].Label.String)
+		assert.Equal(t, "b1", data[2].Label.String)
+	})
+}
+
+func TestSlimPageable(t *testing.T) {
+	t.Run("zero valued pagination fields are omitted", func(t *testing.T) {
+		out, err := json.Marshal(BuildPageable(Pagination{}, 0, []string{}).Slim())
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"data":[]}`, string(out))
+	})
+
+	t.Run("non zero pagination fields are kept", func(t *testing.T) {
+		out, err := json.Marshal(BuildPageable(Pagination{Offset: 0, Limit: 10}, 1, []string{"a"}).Slim())
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":10,"total":1,"data":["a"]}`, string(out))
+	})
+
+	t.Run("Pageable keeps zero valued pagination fields", func(t *testing.T) {
+		out, err := json.Marshal(BuildPageable(Pagination{}, 0, []string{}))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":0,"offset":0,"total":0,"data":[]}`, string(out))
+	})
+}
+
+func TestPageableOf(t *testing.T) {
+	labels := []Label{{Label: NullEmptyString{sql.NullString{String: "hello", Valid: true}}}}
+
+	t.Run("ToUntyped keeps metadata and data", func(t *testing.T) {
+		typed := BuildPageableOf(Pagination{Offset: 10, Limit: 5}, 11, labels)
+
+		out := typed.ToUntyped()
+
+		assert.Equal(t, BuildPageable(Pagination{Offset: 10, Limit: 5}, 11, labels), out)
+	})
+
+	t.Run("typed and untyped pageables marshal identically", func(t *testing.T) {
+		typed, err := json.Marshal(BuildPageableOf(Pagination{Offset: 10, Limit: 5}, 11, labels))
+		require.NoError(t, err)
+		untyped, err := json.Marshal(BuildPageable(Pagination{Offset: 10, Limit: 5}, 11, labels))
+		require.NoError(t, err)
+
+		assert.JSONEq(t, string(untyped), string(typed))
+	})
+
+	t.Run("FromPageable casts unmarshalled data", func(t *testing.T) {
+		pageable := MockPageableLabel("my label")
+
+		out, err := FromPageable[Label](pageable)
+
+		require.NoError(t, err)
+		assert.Equal(t, pageable.Total, out.Total)
+		assert.Equal(t, pageable.Limit, out.Limit)
+		require.Len(t, out.Data, 1)
+		assert.Equal(t, "my label", out.Data[0].Label.String)
+	})
+
+	t.Run("FromPageable returns error when data is not castable", func(t *testing.T) {
+		_, err := FromPageable[Label](Pageable{Data: "pouet"})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestBuildEmptyPageable(t *testing.T) {
+	t.Run("data is marshalled as empty array", func(t *testing.T) {
+		out, err := json.Marshal(BuildEmptyPageable(Pagination{Offset: 0, Limit: 10}, 42))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":10,"offset":0,"total":42,"data":[]}`, string(out))
+	})
+}
+
+func TestResponseFormat(t *testing.T) {
+	format := ResponseFormat
+	t.Cleanup(func() { ResponseFormat = format })
+	page := BuildPageable(Pagination{Offset: 10, Limit: 5}, 42, []string{"a", "b"})
+
+	t.Run("flat format is the default", func(t *testing.T) {
+		assert.Equal(t, FormatFlat, ResponseFormat)
+
+		out, err := json.Marshal(page)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":5,"offset":10,"total":42,"data":["a","b"]}`, string(out))
+	})
+
+	t.Run("meta format nests pagination fields", func(t *testing.T) {
+		ResponseFormat = FormatMeta
+		t.Cleanup(func() { ResponseFormat = FormatFlat })
+
+		out, err := json.Marshal(page)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"meta":{"limit":5,"offset":10,"total":42},"data":["a","b"]}`, string(out))
+	})
+
+	t.Run("typed pageable honors format", func(t *testing.T) {
+		ResponseFormat = FormatMeta
+		t.Cleanup(func() { ResponseFormat = FormatFlat })
+
+		out, err := json.Marshal(BuildPageableOf(Pagination{Offset: 10, Limit: 5}, 42, []string{"a", "b"}))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"meta":{"limit":5,"offset":10,"total":42},"data":["a","b"]}`, string(out))
+	})
+
+	t.Run("data is marshalled under DataFieldName", func(t *testing.T) {
+		t.Cleanup(func() { DataFieldName = "data" })
+		DataFieldName = "items"
+
+		out, err := json.Marshal(page)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":5,"offset":10,"total":42,"items":["a","b"]}`, string(out))
+
+		ResponseFormat = FormatMeta
+		t.Cleanup(func() { ResponseFormat = FormatFlat })
+
+		out, err = json.Marshal(page)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"meta":{"limit":5,"offset":10,"total":42},"items":["a","b"]}`, string(out))
+	})
+}
+
+func TestPageableUnmarshalJSON(t *testing.T) {
+	page := BuildPageable(Pagination{Offset: 10, Limit: 5}, 42, []string{"a", "b"})
+	want := Pageable{Limit: 5, Offset: 10, Total: 42, Data: []interface{}{"a", "b"}}
+
+	roundTrip := func(t *testing.T, page Pageable) Pageable {
+		out, err := json.Marshal(page)
+		require.NoError(t, err)
+
+		var got Pageable
+		require.NoError(t, json.Unmarshal(out, &got))
+		return got
+	}
+
+	t.Run("flat format round trips", func(t *testing.T) {
+		assert.Equal(t, want, roundTrip(t, page))
+	})
+
+	t.Run("meta format round trips", func(t *testing.T) {
+		ResponseFormat = FormatMeta
+		t.Cleanup(func() { ResponseFormat = FormatFlat })
+
+		assert.Equal(t, want, roundTrip(t, page))
+	})
+
+	t.Run("data is read under DataFieldName", func(t *testing.T) {
+		t.Cleanup(func() { ResponseFormat, DataFieldName = FormatFlat, "data" })
+		ResponseFormat, DataFieldName = FormatMeta, "items"
+
+		assert.Equal(t, want, roundTrip(t, page))
+	})
+
+	t.Run("unknown total round trips", func(t *testing.T) {
+		unknown := BuildPageable(Pagination{Offset: 10, Limit: 5}, TotalUnknown, []string{"a", "b"})
+
+		assert.Equal(t, Pageable{Limit: 5, Offset: 10, Total: TotalUnknown, Data: []interface{}{"a", "b"}}, roundTrip(t, unknown))
+	})
+
+	t.Run("malformed JSON returns error", func(t *testing.T) {
+		var got Pageable
+		assert.Error(t, json.Unmarshal([]byte(`{"meta":[]}`), &got))
+	})
+}
+
+func TestMarshalHook(t *testing.T) {
+	type item struct {
+		Name  string `json:"name"`
+		Price int    `json:"price"`
+	}
+	page := BuildPageable(Pagination{Offset: 0, Limit: 2}, 2, []item{{Name: "a", Price: 10}, {Name: "b", Price: 25}})
+
+	t.Run("without hook, elements are marshalled as is", func(t *testing.T) {
+		out, err := json.Marshal(page)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":2,"offset":0,"total":2,"data":[{"name":"a","price":10},{"name":"b","price":25}]}`, string(out))
+	})
+
+	t.Run("hook restructures every element", func(t *testing.T) {
+		t.Cleanup(func() { MarshalHook = nil })
+		MarshalHook = func(element interface{}) interface{} {
+			i := element.(item)
+			return map[string]interface{}{"name": i.Name, "price": i.Price, "priceWithTax": i.Price * 2}
+		}
+
+		out, err := json.Marshal(page)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":2,"offset":0,"total":2,"data":[`+
+			`{"name":"a","price":10,"priceWithTax":20},{"name":"b","price":25,"priceWithTax":50}]}`, string(out))
+	})
+
+	t.Run("hook is applied to typed pageable", func(t *testing.T) {
+		t.Cleanup(func() { MarshalHook = nil })
+		MarshalHook = func(element interface{}) interface{} { return element.(string) + "!" }
+
+		out, err := json.Marshal(BuildPageableOf(Pagination{Offset: 0, Limit: 2}, 1, []string{"a"}))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":2,"offset":0,"total":1,"data":["a!"]}`, string(out))
+	})
+
+	t.Run("hook is not called on nil data", func(t *testing.T) {
+		t.Cleanup(func() { MarshalHook = nil })
+		MarshalHook = func(interface{}) interface{} { panic("unexpected call") }
+
+		out, err := json.Marshal(Pageable{Limit: 2})
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":2,"offset":0,"total":0,"data":null}`, string(out))
+	})
+}
+
+func TestPageableNavigation(t *testing.T) {
+	tests := map[string]struct {
+		pageable       Pageable
+		wantHasNext    bool
+		wantTotalPages int
+	}{
+		"first of several pages": {
+			pageable:       BuildPageable(Pagination{Offset: 0, Limit: 2}, 5, []int{1, 2}),
+			wantHasNext:    true,
+			wantTotalPages: 3,
+		},
+		"last page": {
+			pageable:       BuildPageable(Pagination{Offset: 4, Limit: 2}, 5, []int{5}),
+			wantHasNext:    false,
+			wantTotalPages: 3,
+		},
+		"no rows": {
+			pageable:       BuildPageable(Pagination{Offset: 0, Limit: 2}, 0, []int{}),
+			wantHasNext:    false,
+			wantTotalPages: 0,
+		},
+		"unbounded": {
+			pageable:       BuildPageable(Unbounded(), 3, []int{1, 2, 3}),
+			wantHasNext:    false,
+			wantTotalPages: 1,
+		},
+		"unknown total with full page": {
+			pageable:       BuildPageable(Pagination{Offset: 0, Limit: 2}, TotalUnknown, []int{1, 2}),
+			wantHasNext:    true,
+			wantTotalPages: TotalUnknown,
+		},
+		"unknown total with partial page": {
+			pageable:       BuildPageable(Pagination{Offset: 2, Limit: 2}, TotalUnknown, []int{3}),
+			wantHasNext:    false,
+			wantTotalPages: TotalUnknown,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.wantHasNext, tt.pageable.HasNext())
+			assert.Equal(t, tt.wantTotalPages, tt.pageable.TotalPages())
+		})
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	tests := map[string]struct {
+		pageable Pageable
+		want     int64
+	}{
+		"mid dataset":         {pageable: BuildPageable(Pagination{Offset: 10, Limit: 5}, 42, []int{1, 2, 3, 4, 5}), want: 27},
+		"last page":           {pageable: BuildPageable(Pagination{Offset: 40, Limit: 5}, 42, []int{1, 2}), want: 0},
+		"empty":               {pageable: EmptyPageable(), want: 0},
+		"nil data":            {pageable: Pageable{Offset: 10, Limit: 5, Total: 42}, want: 32},
+		"offset beyond total": {pageable: BuildPageable(Pagination{Offset: 100, Limit: 5}, 42, []int{}), want: 0},
+		"unknown total":       {pageable: BuildPageable(Pagination{Offset: 0, Limit: 2}, TotalUnknown, []int{1, 2}), want: 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.pageable.Remaining())
+		})
+	}
+}
+
+func TestUnknownTotal(t *testing.T) {
+	page := BuildPageable(Pagination{Offset: 0, Limit: 2}, TotalUnknown, []int{1, 2})
+
+	t.Run("total is omitted when marshalled", func(t *testing.T) {
+		out, err := json.Marshal(page)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":2,"offset":0,"data":[1,2]}`, string(out))
+	})
+
+	t.Run("total is omitted from meta when marshalled", func(t *testing.T) {
+		format := ResponseFormat
+		t.Cleanup(func() { ResponseFormat = format })
+		ResponseFormat = FormatMeta
+
+		out, err := json.Marshal(page)
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"meta":{"limit":2,"offset":0},"data":[1,2]}`, string(out))
+	})
+
+	t.Run("CollectAll fetches until a page is not full", func(t *testing.T) {
+		all := []int{1, 2, 3, 4, 5}
+		fetch := func(p Pagination) (Pageable, error) {
+			sliced := BuildPageableSlicing(p, all)
+			sliced.Total = TotalUnknown
+			return sliced, nil
+		}
+
+		got, err := CollectAll[int](context.Background(), fetch, Pagination{Limit: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, all, got)
+	})
+}
+
+func TestSplit(t *testing.T) {
+	tests := map[string]struct {
+		page      Pagination
+		batchSize int
+		want      []Pagination
+	}{
+		"last batch is smaller": {
+			page:      Pagination{Offset: 5, Limit: 100},
+			batchSize: 30,
+			want:      []Pagination{{Offset: 5, Limit: 30}, {Offset: 35, Limit: 30}, {Offset: 65, Limit: 30}, {Offset: 95, Limit: 10}},
+		},
+		"exact batches": {
+			page:      Pagination{Offset: 0, Limit: 60},
+			batchSize: 30,
+			want:      []Pagination{{Offset: 0, Limit: 30}, {Offset: 30, Limit: 30}},
+		},
+		"batch larger than limit": {
+			page:      Pagination{Offset: 0, Limit: 10},
+			batchSize: 30,
+			want:      []Pagination{{Offset: 0, Limit: 10}},
+		},
+		"zero batch size returns original": {
+			page:      Pagination{Offset: 5, Limit: 100},
+			batchSize: 0,
+			want:      []Pagination{{Offset: 5, Limit: 100}},
+		},
+		"negative batch size returns original": {
+			page:      Pagination{Offset: 5, Limit: 100},
+			batchSize: -1,
+			want:      []Pagination{{Offset: 5, Limit: 100}},
+		},
+		"empty page returns original": {
+			page:      Pagination{Offset: 5, Limit: 0, EmptyPage: true},
+			batchSize: 30,
+			want:      []Pagination{{Offset: 5, Limit: 0, EmptyPage: true}},
+		},
+		"unbounded returns original": {
+			page:      Pagination{Offset: 5, Limit: 0},
+			batchSize: 30,
+			want:      []Pagination{{Offset: 5, Limit: 0}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.page.Split(tt.batchSize))
+		})
+	}
+}
+
+func TestValidateOffsetAgainstTotal(t *testing.T) {
+	tests := map[string]struct {
+		page    Pagination
+		total   int64
+		wantErr bool
+	}{
+		"offset within total":       {page: Pagination{Offset: 9, Limit: 10}, total: 10},
+		"offset at total":           {page: Pagination{Offset: 10, Limit: 10}, total: 10, wantErr: true},
+		"offset just past total":    {page: Pagination{Offset: 11, Limit: 10}, total: 10, wantErr: true},
+		"zero total is not checked": {page: Pagination{Offset: 10, Limit: 10}, total: 0},
+		"unknown total is not checked": {
+			page:  Pagination{Offset: 10, Limit: 10},
+			total: TotalUnknown,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateOffsetAgainstTotal(tt.page, tt.total)
+
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var offsetErr OffsetBeyondTotalError
+			require.ErrorAs(t, err, &offsetErr)
+			assert.Equal(t, OffsetBeyondTotalError{Offset: tt.page.Offset, Total: tt.total}, offsetErr)
+			assert.Equal(t, http.StatusNotFound, HTTPStatus(err))
+		})
+	}
+}
+
+func TestIsDeepOffset(t *testing.T) {
+	const threshold = 10000
+	tests := map[string]struct {
+		page          Pagination
+		wantDeep      bool
+		wantSuggested bool
+	}{
+		"below threshold":    {page: Pagination{Offset: threshold - 1, Limit: 10}, wantDeep: false, wantSuggested: false},
+		"at threshold":       {page: Pagination{Offset: threshold, Limit: 10}, wantDeep: false, wantSuggested: false},
+		"above threshold":    {page: Pagination{Offset: threshold + 1, Limit: 10}, wantDeep: true, wantSuggested: true},
+		"unbounded and deep": {page: Pagination{Offset: threshold + 1, Limit: 0}, wantDeep: true, wantSuggested: false},
+		"first page":         {page: Pagination{Offset: 0, Limit: 10}, wantDeep: false, wantSuggested: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.wantDeep, tt.page.IsDeepOffset(threshold))
+			assert.Equal(t, tt.wantSuggested, SuggestKeyset(tt.page, threshold))
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	tests := map[string]struct {
+		page     Pagination
+		maxLimit int
+		want     Pagination
+	}{
+		"valid pagination is kept":                 {page: Pagination{Offset: 10, Limit: 20}, maxLimit: 100, want: Pagination{Offset: 10, Limit: 20}},
+		"negative offset becomes 0":                {page: Pagination{Offset: -10, Limit: 20}, maxLimit: 100, want: Pagination{Offset: 0, Limit: 20}},
+		"negative limit becomes default":           {page: Pagination{Offset: 10, Limit: -5}, maxLimit: 0, want: Pagination{Offset: 10, Limit: DefaultLimit500}},
+		"zero limit becomes default":               {page: Pagination{Offset: 10, Limit: 0}, maxLimit: 0, want: Pagination{Offset: 10, Limit: DefaultLimit500}},
+		"zero limit becomes default capped at max": {page: Pagination{Offset: 10, Limit: 0}, maxLimit: 100, want: Pagination{Offset: 10, Limit: 100}},
+		"over max limit is capped":                 {page: Pagination{Offset: 10, Limit: 1000}, maxLimit: 100, want: Pagination{Offset: 10, Limit: 100}},
+		"zero max limit does not cap":              {page: Pagination{Offset: 10, Limit: 1000}, maxLimit: 0, want: Pagination{Offset: 10, Limit: 1000}},
+		"over max offset is capped":                {page: Pagination{Offset: math.MaxInt, Limit: 20}, maxLimit: 100, want: Pagination{Offset: MaxOffset, Limit: 20}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.page.Clamp(tt.maxLimit))
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	t.Run("Pagination", func(t *testing.T) {
+		page := Pagination{Offset: 0, Limit: 100}
+
+		assert.Equal(t, "offset=0 limit=100", page.String())
+		assert.Equal(t, "offset=0 limit=100", fmt.Sprintf("%+v", page))
+	})
+
+	t.Run("Pageable does not dump data", func(t *testing.T) {
+		pageable := BuildPageable(Pagination{Offset: 0, Limit: 100}, 42, []string{"secret", "rows"})
+
+		assert.Equal(t, "offset=0 limit=100 total=42 len(data)=2", pageable.String())
+		assert.NotContains(t, fmt.Sprintf("%+v", pageable), "secret")
+	})
+}
+
+func TestBuildPageableEstimate(t *testing.T) {
+	t.Run("exact total", func(t *testing.T) {
+		out, err := json.Marshal(BuildPageableEstimate(Pagination{Offset: 0, Limit: 2}, 42, true, []string{"a", "b"}))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":2,"offset":0,"estimated_total":42,"total_is_exact":true,"data":["a","b"]}`, string(out))
+	})
+
+	t.Run("estimated total", func(t *testing.T) {
+		out, err := json.Marshal(BuildPageableEstimate[string](Pagination{Offset: 0, Limit: 2}, 10000, false, nil))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":2,"offset":0,"estimated_total":10000,"total_is_exact":false,"data":[]}`, string(out))
+	})
+}
+
+func TestBuildPageableWithLinks(t *testing.T) {
+	const base = "https://api.example.com/items?sort=name"
+	link := func(offset, limit int) *string {
+		value := fmt.Sprintf("https://api.example.com/items?limit=%d&offset=%d&sort=name", limit, offset)
+		return &value
+	}
+
+	tests := map[string]struct {
+		page Pagination
+		want PageableLinks
+	}{
+		"first page": {
+			page: Pagination{Offset: 0, Limit: 10},
+			want: PageableLinks{Self: link(0, 10), First: link(0, 10), Next: link(10, 10), Last: link(20, 10)},
+		},
+		"middle page": {
+			page: Pagination{Offset: 10, Limit: 10},
+			want: PageableLinks{Self: link(10, 10), First: link(0, 10), Prev: link(0, 10), Next: link(20, 10), Last: link(20, 10)},
+		},
+		"last page": {
+			page: Pagination{Offset: 20, Limit: 10},
+			want: PageableLinks{Self: link(20, 10), First: link(0, 10), Prev: link(10, 10), Last: link(20, 10)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := BuildPageableWithLinks(tt.page, 25, []int{1}, base)
+
+			assert.Equal(t, tt.page.Offset, out.Offset)
+			assert.Equal(t, int64(25), out.Total)
+			assert.Equal(t, tt.want, out.Links)
+		})
+	}
+
+	t.Run("omitted links are dropped from JSON", func(t *testing.T) {
+		out, err := json.Marshal(BuildPageableWithLinks(Pagination{Offset: 0, Limit: 10}, 5, []int{1}, "/items"))
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":10,"offset":0,"total":5,"data":[1],"links":{
+			"self":"/items?limit=10&offset=0","first":"/items?limit=10&offset=0","last":"/items?limit=10&offset=0"}}`, string(out))
+	})
+
+	t.Run("unparseable base url gives no links", func(t *testing.T) {
+		out := BuildPageableWithLinks(Pagination{Offset: 10, Limit: 10}, 25, []int{1}, "://items")
+
+		assert.Equal(t, PageableLinks{}, out.Links)
+	})
+}
+
+func TestEmptyPageable(t *testing.T) {
+	t.Run("data is marshalled as empty array", func(t *testing.T) {
+		out, err := json.Marshal(EmptyPageable())
+
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"limit":0,"offset":0,"total":0,"data":[]}`, string(out))
+	})
+
+	t.Run("IsEmpty reports zero total", func(t *testing.T) {
+		assert.True(t, EmptyPageable().IsEmpty())
+		assert.True(t, Pageable{}.IsEmpty())
+		assert.False(t, BuildEmptyPageable(Pagination{Offset: 100, Limit: 10}, 42).IsEmpty())
+		assert.False(t, BuildPageable(Pagination{Limit: 10}, 1, []int{1}).IsEmpty())
+	})
+}
+
+func TestWithData(t *testing.T) {
+	original := BuildPageable(Pagination{Offset: 10, Limit: 5}, 42, []string{"a"})
+
+	out := original.WithData([]int{1, 2})
+
+	assert.Equal(t, Pageable{Limit: 5, Offset: 10, Total: 42, Data: []int{1, 2}}, out)
+	assert.Equal(t, []string{"a"}, original.Data)
+}
+
+func TestMapPageable(t *testing.T) {
+	type labelDTO struct {
+		Name string
+	}
+	toDTO := func(label Label) labelDTO {
+		return labelDTO{Name: label.Label.String}
+	}
+
+	t.Run("When data is not castable, returns error", func(t *testing.T) {
+		_, err := MapPageable(Pageable{Data: int64(1)}, toDTO)
+		assert.Error(t, err)
+	})
+
+	t.Run("nominal", func(t *testing.T) {
+		pageable := MockPageableLabel("my label", "my second label")
+		pageable.Offset, pageable.Limit, pageable.Total = 10, 2, 12
+
+		out, err := MapPageable(pageable, toDTO)
+
+		require.NoError(t, err)
+		assert.Equal(t, 10, out.Offset)
+		assert.Equal(t, 2, out.Limit)
+		assert.Equal(t, int64(12), out.Total)
+		assert.Equal(t, []labelDTO{{Name: "my label"}, {Name: "my second label"}}, out.Data)
+	})
+}
+
+func TestFilterPageable(t *testing.T) {
+	keep := func(label Label) bool {
+		return strings.HasPrefix(label.Label.String, "keep")
+	}
+
+	t.Run("When data is not castable, returns error", func(t *testing.T) {
+		_, err := FilterPageable(Pageable{Data: int64(1)}, keep)
+		assert.Error(t, err)
+	})
+
+	t.Run("nominal", func(t *testing.T) {
+		pageable := MockPageableLabel("keep first", "drop", "keep second")
+		pageable.Offset, pageable.Limit = 10, 3
+
+		out, err := FilterPageable(pageable, keep)
+
+		require.NoError(t, err)
+		assert.Equal(t, 10, out.Offset)
+		assert.Equal(t, 3, out.Limit)
+		assert.Equal(t, int64(2), out.Total)
+		labels, err := PageableToSlice[Label](out)
+		require.NoError(t, err)
+		require.Len(t, labels, 2)
+		assert.Equal(t, "keep first", labels[0].Label.String)
+		assert.Equal(t, "keep second", labels[1].Label.String)
+	})
+}
+
+func TestApplyFieldSelection(t *testing.T) {
+	newPageable := func() Pageable {
+		pageable := MockPageableLabel("first", "second")
+		for _, element := range pageable.Data.([]interface{}) {
+			element.(map[string]interface{})["id"] = 1
+			element.(map[string]interface{})["color"] = "red"
+		}
+		return pageable
+	}
+
+	t.Run("When data is not castable, returns error", func(t *testing.T) {
+		_, err := ApplyFieldSelection(Pageable{Data: int64(1)}, []string{"id"})
+		assert.Error(t, err)
+	})
+
+	t.Run("keeps only selected fields, ignoring unknown ones", func(t *testing.T) {
+		pageable := newPageable()
+
+		out, err := ApplyFieldSelection(pageable, []string{"id", "label", "unknown"})
+
+		require.NoError(t, err)
+		assert.Equal(t, pageable.Total, out.Total)
+		body, err := json.Marshal(out.Data)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"id":1,"label":"first"},{"id":1,"label":"second"}]`, string(body))
+	})
+
+	t.Run("keeps only selected fields of typed data", func(t *testing.T) {
+		labels := []Label{
+			{Label: NullEmptyString{sql.NullString{String: "first", Valid: true}}},
+			{Label: NullEmptyString{sql.NullString{String: "second", Valid: true}}},
+		}
+		pageable := BuildPageable(Pagination{Offset: 0, Limit: 10}, 2, labels)
+
+		out, err := ApplyFieldSelection(pageable, []string{"label", "unknown"})
+
+		require.NoError(t, err)
+		assert.Equal(t, pageable.Total, out.Total)
+		body, err := json.Marshal(out.Data)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[{"label":"first"},{"label":"second"}]`, string(body))
+	})
+
+	t.Run("no selection keeps all fields", func(t *testing.T) {
+		pageable := newPageable()
+
+		out, err := ApplyFieldSelection(pageable, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, pageable, out)
+	})
+}
+
+func TestApplyDelta(t *testing.T) {
+	key := func(label Label) string {
+		return label.Label.String
+	}
+	newLabel := func(value string) map[string]interface{} {
+		return map[string]interface{}{"label": value}
+	}
+
+	t.Run("When data is not castable, returns error", func(t *testing.T) {
+		_, err := ApplyDelta(Pageable{Data: int64(1)}, PageableDelta{}, key)
+		assert.Error(t, err)
+	})
+
+	t.Run("adds and removes elements and updates total", func(t *testing.T) {
+		base := MockPageableLabel("first", "second", "third")
+		base.Offset, base.Limit, base.Total = 0, 10, 3
+
+		out, err := ApplyDelta(base, PageableDelta{
+			Added:   []interface{}{newLabel("fourth"), newLabel("fifth")},
+			Removed: []interface{}{newLabel("second")},
+			Total:   4,
+		}, key)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, out.Offset)
+		assert.Equal(t, 10, out.Limit)
+		assert.Equal(t, int64(4), out.Total)
+		labels, err := PageableToSlice[Label](out)
+		require.NoError(t, err)
+		var values []string
+		for _, label := range labels {
+			values = append(values, key(label))
+		}
+		assert.Equal(t, []string{"first", "third", "fourth", "fifth"}, values)
+	})
+
+	t.Run("added element already present replaces it in place", func(t *testing.T) {
+		type item struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		base := BuildPageable(Pagination{Limit: 10}, 2, []item{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}})
+
+		out, err := ApplyDelta(base, PageableDelta{
+			Added: []interface{}{item{ID: 1, Name: "uno"}},
+			Total: 2,
+		}, func(i item) int { return i.ID })
+
+		require.NoError(t, err)
+		assert.Equal(t, []item{{ID: 1, Name: "uno"}, {ID: 2, Name: "two"}}, out.Data)
+		assert.Equal(t, int64(2), out.Total)
+	})
+}
+
+func TestBuildPageableConcurrent(t *testing.T) {
+	count := func(context.Context) (int64, error) {
+		return 42, nil
+	}
+	fetch := func(_ context.Context, page Pagination) ([]string, error) {
+		return []string{"a", "b"}, nil
+	}
+
+	t.Run("nominal", func(t *testing.T) {
+		page := Pagination{Offset: 10, Limit: 2}
+
+		out, err := BuildPageableConcurrent(context.Background(), page, count, fetch)
+
+		require.NoError(t, err)
+		assert.Equal(t, BuildPageable(page, 42, []string{"a", "b"}), out)
+	})
+
+	t.Run("when count fails, returns error and cancels data fetch", func(t *testing.T) {
+		countErr := errors.New("count failed")
+		cancelled := make(chan struct{})
+		failingCount := func(context.Context) (int64, error) {
+			return 0, countErr
+		}
+		blockingFetch := func(ctx context.Context, _ Pagination) ([]string, error) {
+			<-ctx.Done()
+			close(cancelled)
+			return nil, ctx.Err()
+		}
+
+		_, err := BuildPageableConcurrent(context.Background(), Pagination{}, failingCount, blockingFetch)
+
+		assert.ErrorIs(t, err, countErr)
+		<-cancelled
+	})
+
+	t.Run("when data fetch fails, returns error", func(t *testing.T) {
+		fetchErr := errors.New("fetch failed")
+		failingFetch := func(context.Context, Pagination) ([]string, error) {
+			return nil, fetchErr
+		}
+
+		_, err := BuildPageableConcurrent(context.Background(), Pagination{}, count, failingFetch)
+
+		assert.ErrorIs(t, err, fetchErr)
+	})
+
+	t.Run("when context is cancelled, returns context error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+		blockingCount := func(context.Context) (int64, error) {
+			<-release
+			return 0, nil
+		}
+		cancel()
+
+		_, err := BuildPageableConcurrent(ctx, Pagination{}, blockingCount, fetch)
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestBuildPageableSlicing(t *testing.T) {
+	allData := []int{0, 1, 2, 3, 4, 5, 6}
+	tests := map[string]struct {
+		page     Pagination
+		wantData []int
+	}{
+		"first page":                    {page: Pagination{Offset: 0, Limit: 3}, wantData: []int{0, 1, 2}},
+		"partial last page":             {page: Pagination{Offset: 6, Limit: 3}, wantData: []int{6}},
+		"offset at length is empty":     {page: Pagination{Offset: 7, Limit: 3}, wantData: []int{}},
+		"offset beyond length is empty": {page: Pagination{Offset: 100, Limit: 3}, wantData: []int{}},
+		"zero limit keeps remaining":    {page: Pagination{Offset: 4, Limit: 0}, wantData: []int{4, 5, 6}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := BuildPageableSlicing(tt.page, allData)
+
+			assert.Equal(t, int64(len(allData)), out.Total)
+			assert.Equal(t, tt.page.Offset, out.Offset)
+			assert.Equal(t, tt.page.Limit, out.Limit)
+			assert.Equal(t, tt.wantData, out.Data)
+		})
+	}
+
+	t.Run("nil data is empty", func(t *testing.T) {
+		out := BuildPageableSlicing[int](Pagination{Offset: 0, Limit: 3}, nil)
+
+		assert.Equal(t, int64(0), out.Total)
+		assert.Equal(t, []int{}, out.Data)
+	})
+}
+
+func TestCollectAll(t *testing.T) {
+	labels := func(from, to int) []Label {
+		var data []Label
+		for i := from; i < to; i++ {
+			data = append(data, Label{Label: NullEmptyString{sql.NullString{String: strconv.Itoa(i), Valid: true}}})
+		}
+		return data
+	}
+	all := labels(0, 7)
+
+	t.Run("collects all pages", func(t *testing.T) {
+		var calls []Pagination
+		fetch := func(p Pagination) (Pageable, error) {
+			calls = append(calls, p)
+			return BuildPageableSlicing(p, all), nil
+		}
+
+		got, err := CollectAll[Label](context.Background(), fetch, Pagination{Offset: 0, Limit: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, all, got)
+		assert.Equal(t, []Pagination{{Offset: 0, Limit: 3}, {Offset: 3, Limit: 3}, {Offset: 6, Limit: 3}}, calls)
+	})
+
+	t.Run("collects from start offset", func(t *testing.T) {
+		fetch := func(p Pagination) (Pageable, error) {
+			return BuildPageableSlicing(p, all), nil
+		}
+
+		got, err := CollectAll[Label](context.Background(), fetch, Pagination{Offset: 5, Limit: 3})
+
+		require.NoError(t, err)
+		assert.Equal(t, all[5:], got)
+	})
+
+	t.Run("when source is empty, returns empty slice", func(t *testing.T) {
+		fetch := func(p Pagination) (Pageable, error) {
+			return BuildPageable(p, 0, []Label{}), nil
+		}
+
+		got, err := CollectAll[Label](context.Background(), fetch, Pagination{Limit: 3})
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("when fetch fails, returns its error", func(t *testing.T) {
+		fetchErr := errors.New("boom")
+		fetch := func(p Pagination) (Pageable, error) {
+			return Pageable{}, fetchErr
+		}
+
+		_, err := CollectAll[Label](context.Background(), fetch, Pagination{Limit: 3})
+
+		assert.ErrorIs(t, err, fetchErr)
+	})
+
+	t.Run("when total keeps growing, stops with an error", func(t *testing.T) {
+		calls := 0
+		fetch := func(p Pagination) (Pageable, error) {
+			calls++
+			return BuildPageable(p, int64(p.Offset+10), labels(p.Offset, p.Offset+p.Limit)), nil
+		}
+
+		_, err := CollectAll[Label](context.Background(), fetch, Pagination{Limit: 3})
+
+		assert.Error(t, err)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("when page is empty before total is reached, stops with an error", func(t *testing.T) {
+		fetch := func(p Pagination) (Pageable, error) {
+			return BuildPageable(p, 10, []Label{}), nil
+		}
+
+		_, err := CollectAll[Label](context.Background(), fetch, Pagination{Limit: 3})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("when context is cancelled, returns its error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := CollectAll[Label](ctx, func(p Pagination) (Pageable, error) {
+			return BuildPageableSlicing(p, all), nil
+		}, Pagination{Limit: 3})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
 }
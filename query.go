@@ -0,0 +1,90 @@
+package pagination
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetBoolFromURLQuery gets boolean ("true", "false", "1" or "0") from url query key
+// Returns an invalid NullBool if key is absent, so that "not provided" can be told apart from false
+func GetBoolFromURLQuery(c *gin.Context, key string) (NullBool, error) {
+	value, ok := c.GetQuery(key)
+	if !ok {
+		return NullBool{}, nil
+	}
+
+	var nb NullBool
+	if err := nb.scanString(value); err != nil {
+		return NullBool{}, BadRequestValueError{Key: key, Value: value, Err: err}
+	}
+	return nb, nil
+}
+
+// GetNullIntFromURLQuery gets integer from url query key
+// Returns an invalid NullInt if key is absent, a present 0 giving a valid NullInt so that "not filtered" can be told apart from 0
+func GetNullIntFromURLQuery(c *gin.Context, key string) (NullInt, error) {
+	value, ok := c.GetQuery(key)
+	if !ok {
+		return NullInt{}, nil
+	}
+
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return NullInt{}, BadRequestValueError{Key: key, Value: value, Err: err}
+	}
+	return NullInt{sql.NullInt64{Int64: i, Valid: true}}, nil
+}
+
+// SearchKey is the url query key holding free-text search, see GetSearchFromURLQuery
+const SearchKey = "q"
+
+// GetSearchFromURLQuery gets trimmed free-text search from url query key SearchKey
+// Returns an invalid NullString if key is absent or blank
+func GetSearchFromURLQuery(c *gin.Context) NullString {
+	value := strings.TrimSpace(c.Query(SearchKey))
+	if value == "" {
+		return NullString{}
+	}
+	return NullString{sql.NullString{String: value, Valid: true}}
+}
+
+// GetTimeRangeFromURLQuery gets a time range from url query keys fromKey and toKey, parsed with NullTime layouts
+// Absent keys give invalid NullTime, a BadRequestValueError is returned if a value cannot be parsed or from is after to
+func GetTimeRangeFromURLQuery(c *gin.Context, fromKey, toKey string) (from, to NullTime, err error) {
+	from, err = getNullTimeFromURLQuery(c, fromKey)
+	if err != nil {
+		return NullTime{}, NullTime{}, err
+	}
+
+	to, err = getNullTimeFromURLQuery(c, toKey)
+	if err != nil {
+		return NullTime{}, NullTime{}, err
+	}
+
+	if from.Valid && to.Valid && from.Time.After(to.Time) {
+		return NullTime{}, NullTime{}, BadRequestValueError{
+			Key:   fromKey,
+			Value: c.Query(fromKey),
+			Err:   fmt.Errorf("%s cannot be after %s", fromKey, toKey),
+		}
+	}
+	return from, to, nil
+}
+
+// getNullTimeFromURLQuery gets time from url query key, invalid if key is absent
+func getNullTimeFromURLQuery(c *gin.Context, key string) (NullTime, error) {
+	value, ok := c.GetQuery(key)
+	if !ok {
+		return NullTime{}, nil
+	}
+
+	t, err := parseNullTime(value)
+	if err != nil {
+		return NullTime{}, BadRequestValueError{Key: key, Value: value, Err: err}
+	}
+	return NullTime{sql.NullTime{Time: t, Valid: true}}, nil
+}
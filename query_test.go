@@ -0,0 +1,186 @@
+package pagination
+
+import (
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQueryContext returns a gin context for a GET request on url
+func newQueryContext(url string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, url, nil)
+	return c
+}
+
+func TestGetBoolFromURLQuery(t *testing.T) {
+	tests := map[string]struct {
+		url     string
+		want    NullBool
+		wantErr bool
+	}{
+		"when key is absent, returns invalid": {
+			url:  "/",
+			want: NullBool{},
+		},
+		"true": {
+			url:  "/?active=true",
+			want: NullBool{sql.NullBool{Bool: true, Valid: true}},
+		},
+		"false": {
+			url:  "/?active=false",
+			want: NullBool{sql.NullBool{Bool: false, Valid: true}},
+		},
+		"1": {
+			url:  "/?active=1",
+			want: NullBool{sql.NullBool{Bool: true, Valid: true}},
+		},
+		"0": {
+			url:  "/?active=0",
+			want: NullBool{sql.NullBool{Bool: false, Valid: true}},
+		},
+		"when value is garbage, returns error": {
+			url:     "/?active=pouet",
+			wantErr: true,
+		},
+		"when value is empty, returns error": {
+			url:     "/?active=",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := GetBoolFromURLQuery(newQueryContext(tt.url), "active")
+			if tt.wantErr {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, err, &valueErr)
+				assert.Equal(t, "active", valueErr.Key)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestGetNullIntFromURLQuery(t *testing.T) {
+	tests := map[string]struct {
+		url     string
+		want    NullInt
+		wantErr bool
+	}{
+		"when key is absent, returns invalid": {
+			url:  "/",
+			want: NullInt{},
+		},
+		"when value is 0, returns valid zero": {
+			url:  "/?count=0",
+			want: NullInt{sql.NullInt64{Int64: 0, Valid: true}},
+		},
+		"when value is an integer, returns it": {
+			url:  "/?count=-42",
+			want: NullInt{sql.NullInt64{Int64: -42, Valid: true}},
+		},
+		"when value is garbage, returns error": {
+			url:     "/?count=pouet",
+			wantErr: true,
+		},
+		"when value is empty, returns error": {
+			url:     "/?count=",
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out, err := GetNullIntFromURLQuery(newQueryContext(tt.url), "count")
+			if tt.wantErr {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, err, &valueErr)
+				assert.Equal(t, "count", valueErr.Key)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, out)
+		})
+	}
+}
+
+func TestGetTimeRangeFromURLQuery(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		wantFrom NullTime
+		wantTo   NullTime
+		wantErr  string
+	}{
+		"when keys are absent, returns invalid times": {
+			url: "/",
+		},
+		"when only from is present, returns open range": {
+			url:      "/?from=2023-01-01",
+			wantFrom: validNullTime(2023, 1, 1),
+		},
+		"nominal": {
+			url:      "/?from=2023-01-01&to=2023-01-31",
+			wantFrom: validNullTime(2023, 1, 1),
+			wantTo:   validNullTime(2023, 1, 31),
+		},
+		"RFC3339 layout": {
+			url:      "/?from=2023-01-01T00:00:00Z&to=2023-01-31",
+			wantFrom: validNullTime(2023, 1, 1),
+			wantTo:   validNullTime(2023, 1, 31),
+		},
+		"when from is equal to to, returns range": {
+			url:      "/?from=2023-01-01&to=2023-01-01",
+			wantFrom: validNullTime(2023, 1, 1),
+			wantTo:   validNullTime(2023, 1, 1),
+		},
+		"when range is inverted, returns error": {
+			url:     "/?from=2023-02-01&to=2023-01-31",
+			wantErr: "from",
+		},
+		"when from is malformed, returns error": {
+			url:     "/?from=01/02/2023&to=2023-01-31",
+			wantErr: "from",
+		},
+		"when to is malformed, returns error": {
+			url:     "/?from=2023-01-01&to=pouet",
+			wantErr: "to",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, to, err := GetTimeRangeFromURLQuery(newQueryContext(tt.url), "from", "to")
+			if tt.wantErr != "" {
+				var valueErr BadRequestValueError
+				require.ErrorAs(t, err, &valueErr)
+				assert.Equal(t, tt.wantErr, valueErr.Key)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFrom, from)
+			assert.Equal(t, tt.wantTo, to)
+		})
+	}
+}
+
+func TestGetSearchFromURLQuery(t *testing.T) {
+	tests := map[string]struct {
+		url  string
+		want NullString
+	}{
+		"when key is absent, returns invalid":  {url: "/", want: NullString{}},
+		"when value is blank, returns invalid": {url: "/?q=%20%20", want: NullString{}},
+		"value is trimmed":                     {url: "/?q=%20foo%20", want: NullString{sql.NullString{String: "foo", Valid: true}}},
+		"wildcards are kept as is":             {url: "/?q=50%25_off", want: NullString{sql.NullString{String: "50%_off", Valid: true}}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GetSearchFromURLQuery(newQueryContext(tt.url)))
+		})
+	}
+}
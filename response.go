@@ -0,0 +1,172 @@
+package pagination
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Error codes set in ResponseError by NewResponseError
+const (
+	CodeNotFound              = "not_found"
+	CodeBadRequestKey         = "bad_request_key"
+	CodeBadRequestValue       = "bad_request_value"
+	CodeMissingQueryParameter = "missing_query_parameter"
+	CodeLimitTooLarge         = "limit_too_large"
+	CodeOffsetBeyondTotal     = "offset_beyond_total"
+	CodeRepository            = "repository_error"
+	CodeDeletePeriod          = "delete_period_error"
+	CodeRowsAffected          = "rows_affected_error"
+	CodeInternal              = "internal_error"
+)
+
+// NewResponseError builds ResponseError from err, with its message and the code matching the package error type
+func NewResponseError(err error) ResponseError {
+	if err == nil {
+		return ResponseError{}
+	}
+
+	code, _ := classifyError(err)
+	return ResponseError{Message: err.Error(), Code: code}
+}
+
+// HTTPStatus returns the HTTP status matching the package error type of err, 500 for unknown errors
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	_, status := classifyError(err)
+	return status
+}
+
+// classifyError returns the error code and HTTP status of err
+func classifyError(err error) (string, int) {
+	switch {
+	case errors.As(err, &NotFoundError{}):
+		return CodeNotFound, http.StatusNotFound
+	case errors.As(err, &BadRequestKeyError{}):
+		return CodeBadRequestKey, http.StatusBadRequest
+	case errors.As(err, &BadRequestValueError{}):
+		return CodeBadRequestValue, http.StatusBadRequest
+	case errors.As(err, &MissingQueryParameterError{}):
+		return CodeMissingQueryParameter, http.StatusBadRequest
+	case errors.As(err, &LimitTooLargeError{}):
+		return CodeLimitTooLarge, http.StatusBadRequest
+	case errors.As(err, &OffsetBeyondTotalError{}):
+		return CodeOffsetBeyondTotal, http.StatusNotFound
+	case errors.As(err, &RowsAffectedError{}):
+		return CodeRowsAffected, http.StatusInternalServerError
+	case errors.As(err, &DeletePeriodError{}):
+		return CodeDeletePeriod, http.StatusInternalServerError
+	case errors.As(err, &RepositoryError{}):
+		return CodeRepository, http.StatusInternalServerError
+	default:
+		return CodeInternal, http.StatusInternalServerError
+	}
+}
+
+// RespondPageableCompressed writes pageable as JSON with status, gzip-compressed when its encoded size exceeds threshold
+// and the client accepts gzip encoding, plain otherwise
+// Aborts with a ResponseError body if pageable cannot be marshalled
+func RespondPageableCompressed(c *gin.Context, status int, p Pageable, threshold int) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, NewResponseError(err))
+		return
+	}
+
+	c.Header("Vary", "Accept-Encoding")
+	if len(body) <= threshold || !acceptsGzip(c.GetHeader("Accept-Encoding")) {
+		c.Data(status, gin.MIMEJSON+"; charset=utf-8", body)
+		return
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(body); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, NewResponseError(err))
+		return
+	}
+	if err := writer.Close(); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, NewResponseError(err))
+		return
+	}
+
+	c.Header("Content-Encoding", "gzip")
+	c.Data(status, gin.MIMEJSON+"; charset=utf-8", compressed.Bytes())
+}
+
+// RespondPageableCached writes pageable as JSON with status and a Cache-Control max-age set from ttlFn(p),
+// in whole seconds, a negative ttl giving max-age=0
+func RespondPageableCached(c *gin.Context, status int, p Pageable, ttlFn func(Pageable) time.Duration) {
+	seconds := max(int64(ttlFn(p)/time.Second), 0)
+	c.Header("Cache-Control", "max-age="+strconv.FormatInt(seconds, 10))
+	c.JSON(status, p)
+}
+
+// PageableETag returns a strong ETag, quoted as sent in the ETag header, computed as the SHA-256 of pageable JSON encoding
+// The ETag is deterministic since encoding/json sorts map keys
+func PageableETag(p Pageable) (string, error) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:]) + `"`, nil
+}
+
+// RespondPageableETag writes pageable as JSON with its ETag header, or an empty 304 Not Modified
+// if the ETag is listed by the If-None-Match request header
+// Aborts with a ResponseError body if pageable cannot be marshalled
+func RespondPageableETag(c *gin.Context, p Pageable) {
+	etag, err := PageableETag(p)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, NewResponseError(err))
+		return
+	}
+
+	c.Header("ETag", etag)
+	if matchesETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// matchesETag returns true if If-None-Match header value is "*" or lists etag, weak comparison being used as per RFC 9110
+func matchesETag(header, etag string) bool {
+	for _, part := range strings.Split(header, ",") {
+		candidate := strings.TrimSpace(part)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// acceptsGzip returns true if Accept-Encoding header value lists gzip without a zero quality
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			continue
+		}
+		if quality, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			value, err := strconv.ParseFloat(quality, 64)
+			return err == nil && value > 0
+		}
+		return true
+	}
+	return false
+}
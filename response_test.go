@@ -0,0 +1,235 @@
+package pagination
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResponseError(t *testing.T) {
+	tests := map[string]struct {
+		err        error
+		wantCode   string
+		wantStatus int
+	}{
+		"not found": {
+			err:        NotFoundError{Entity: Label{}},
+			wantCode:   CodeNotFound,
+			wantStatus: http.StatusNotFound,
+		},
+		"bad request key": {
+			err:        BadRequestKeyError{Key: "id"},
+			wantCode:   CodeBadRequestKey,
+			wantStatus: http.StatusBadRequest,
+		},
+		"bad request value": {
+			err:        BadRequestValueError{Key: "limit", Err: errors.New("pouet")},
+			wantCode:   CodeBadRequestValue,
+			wantStatus: http.StatusBadRequest,
+		},
+		"wrapped bad request value": {
+			err:        fmt.Errorf("parsing: %w", BadRequestValueError{Key: "limit", Value: -1}),
+			wantCode:   CodeBadRequestValue,
+			wantStatus: http.StatusBadRequest,
+		},
+		"missing query parameter": {
+			err:        MissingQueryParameterError{Key: "id"},
+			wantCode:   CodeMissingQueryParameter,
+			wantStatus: http.StatusBadRequest,
+		},
+		"limit too large": {
+			err:        LimitTooLargeError{Requested: 1000, Max: 100},
+			wantCode:   CodeLimitTooLarge,
+			wantStatus: http.StatusBadRequest,
+		},
+		"offset beyond total": {
+			err:        OffsetBeyondTotalError{Offset: 100, Total: 10},
+			wantCode:   CodeOffsetBeyondTotal,
+			wantStatus: http.StatusNotFound,
+		},
+		"rows affected": {
+			err:        RowsAffectedError{Usecase: "update", AffectedRows: 0, ExpectedRows: 1},
+			wantCode:   CodeRowsAffected,
+			wantStatus: http.StatusInternalServerError,
+		},
+		"delete period": {
+			err:        DeletePeriodError{Usecase: "delete", Err: errors.New("pouet")},
+			wantCode:   CodeDeletePeriod,
+			wantStatus: http.StatusInternalServerError,
+		},
+		"repository": {
+			err:        RepositoryError{Usecase: "list", Err: errors.New("pouet")},
+			wantCode:   CodeRepository,
+			wantStatus: http.StatusInternalServerError,
+		},
+		"unknown": {
+			err:        errors.New("pouet"),
+			wantCode:   CodeInternal,
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			out := NewResponseError(tt.err)
+
+			assert.Equal(t, tt.err.Error(), out.Message)
+			assert.Equal(t, tt.wantCode, out.Code)
+			assert.Equal(t, tt.wantStatus, HTTPStatus(tt.err))
+		})
+	}
+
+	t.Run("nil error", func(t *testing.T) {
+		assert.Equal(t, ResponseError{}, NewResponseError(nil))
+		assert.Equal(t, http.StatusOK, HTTPStatus(nil))
+	})
+}
+
+func TestRespondPageableCompressed(t *testing.T) {
+	pageable := BuildPageable(Pagination{Offset: 0, Limit: 100}, 100, []string{strings.Repeat("label", 100)})
+	want, err := json.Marshal(pageable)
+	require.NoError(t, err)
+
+	respond := func(acceptEncoding string, threshold int) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rw)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if acceptEncoding != "" {
+			c.Request.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		RespondPageableCompressed(c, http.StatusOK, pageable, threshold)
+		return rw
+	}
+
+	t.Run("when body exceeds threshold and client accepts gzip, compresses", func(t *testing.T) {
+		rw := respond("deflate, gzip", 100)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "gzip", rw.Header().Get("Content-Encoding"))
+		reader, err := gzip.NewReader(rw.Body)
+		require.NoError(t, err)
+		got, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(got))
+	})
+
+	t.Run("when body is under threshold, writes plain JSON", func(t *testing.T) {
+		rw := respond("gzip", len(want))
+
+		assert.Empty(t, rw.Header().Get("Content-Encoding"))
+		assert.JSONEq(t, string(want), rw.Body.String())
+	})
+
+	t.Run("when client does not accept gzip, writes plain JSON", func(t *testing.T) {
+		for _, acceptEncoding := range []string{"", "deflate", "gzip;q=0"} {
+			rw := respond(acceptEncoding, 100)
+
+			assert.Empty(t, rw.Header().Get("Content-Encoding"), acceptEncoding)
+			assert.JSONEq(t, string(want), rw.Body.String(), acceptEncoding)
+		}
+	})
+}
+
+func TestRespondPageableCached(t *testing.T) {
+	ttl := func(p Pageable) time.Duration {
+		if p.Offset >= 1000 {
+			return time.Hour
+		}
+		return 30 * time.Second
+	}
+	respond := func(p Pageable, ttlFn func(Pageable) time.Duration) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rw)
+		RespondPageableCached(c, http.StatusOK, p, ttlFn)
+		return rw
+	}
+
+	t.Run("shallow page gets short ttl", func(t *testing.T) {
+		pageable := BuildPageable(Pagination{Offset: 0, Limit: 10}, 2000, []string{"a"})
+
+		rw := respond(pageable, ttl)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, "max-age=30", rw.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{"limit":10,"offset":0,"total":2000,"data":["a"]}`, rw.Body.String())
+	})
+
+	t.Run("deep page gets long ttl", func(t *testing.T) {
+		rw := respond(BuildPageable(Pagination{Offset: 1500, Limit: 10}, 2000, []string{"a"}), ttl)
+
+		assert.Equal(t, "max-age=3600", rw.Header().Get("Cache-Control"))
+	})
+
+	t.Run("negative ttl gives max-age=0", func(t *testing.T) {
+		rw := respond(EmptyPageable(), func(Pageable) time.Duration { return -time.Minute })
+
+		assert.Equal(t, "max-age=0", rw.Header().Get("Cache-Control"))
+	})
+}
+
+func TestRespondPageableETag(t *testing.T) {
+	pageable := BuildPageable(Pagination{Offset: 0, Limit: 10}, 2, []map[string]int{{"b": 2, "a": 1}, {"c": 3}})
+	etag, err := PageableETag(pageable)
+	require.NoError(t, err)
+
+	respond := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rw)
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		if ifNoneMatch != "" {
+			c.Request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		RespondPageableETag(c, pageable)
+		c.Writer.WriteHeaderNow()
+		return rw
+	}
+
+	t.Run("ETag is deterministic", func(t *testing.T) {
+		for i := 0; i < 10; i++ {
+			other, err := PageableETag(BuildPageable(Pagination{Offset: 0, Limit: 10}, 2, []map[string]int{{"a": 1, "b": 2}, {"c": 3}}))
+			require.NoError(t, err)
+			assert.Equal(t, etag, other)
+		}
+	})
+
+	t.Run("ETag changes with content", func(t *testing.T) {
+		other, err := PageableETag(BuildPageable(Pagination{Offset: 10, Limit: 10}, 2, []map[string]int{}))
+		require.NoError(t, err)
+		assert.NotEqual(t, etag, other)
+	})
+
+	t.Run("without If-None-Match, writes body and ETag", func(t *testing.T) {
+		rw := respond("")
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.Equal(t, etag, rw.Header().Get("ETag"))
+		assert.JSONEq(t, `{"limit":10,"offset":0,"total":2,"data":[{"a":1,"b":2},{"c":3}]}`, rw.Body.String())
+	})
+
+	t.Run("matching If-None-Match gives 304", func(t *testing.T) {
+		for _, ifNoneMatch := range []string{etag, `"other", ` + etag, "W/" + etag, "*"} {
+			rw := respond(ifNoneMatch)
+
+			assert.Equal(t, http.StatusNotModified, rw.Code, ifNoneMatch)
+			assert.Equal(t, etag, rw.Header().Get("ETag"), ifNoneMatch)
+			assert.Empty(t, rw.Body.String(), ifNoneMatch)
+		}
+	})
+
+	t.Run("stale If-None-Match writes body", func(t *testing.T) {
+		rw := respond(`"stale"`)
+
+		assert.Equal(t, http.StatusOK, rw.Code)
+		assert.NotEmpty(t, rw.Body.String())
+	})
+}
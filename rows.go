@@ -0,0 +1,26 @@
+package pagination
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ScanPageable builds pageable from rows, calling scan on each row to collect data, and closes rows
+// Returns the first error of scan or of rows iteration
+func ScanPageable[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error), page Pagination, total int64) (Pageable, error) {
+	defer rows.Close()
+
+	var data []T
+	for rows.Next() {
+		value, err := scan(rows)
+		if err != nil {
+			return Pageable{}, fmt.Errorf("unable to scan row for %T datatype: %w", value, err)
+		}
+		data = append(data, value)
+	}
+	if err := rows.Err(); err != nil {
+		return Pageable{}, fmt.Errorf("unable to iterate rows: %w", err)
+	}
+
+	return BuildPageable(page, total, data), nil
+}
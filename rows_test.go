@@ -0,0 +1,75 @@
+package pagination
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanPageable(t *testing.T) {
+	type label struct {
+		ID   int64
+		Name string
+	}
+	page := Pagination{Offset: 0, Limit: 10}
+	scanLabel := func(rows *sql.Rows) (label, error) {
+		var l label
+		err := rows.Scan(&l.ID, &l.Name)
+		return l, err
+	}
+
+	query := func(t *testing.T, rows *sqlmock.Rows) (*sql.Rows, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		mock.ExpectQuery("SELECT").WillReturnRows(rows).RowsWillBeClosed()
+		result, err := db.Query("SELECT id, name FROM label")
+		require.NoError(t, err)
+		return result, mock
+	}
+
+	t.Run("collects scanned rows", func(t *testing.T) {
+		rows, mock := query(t, sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a").AddRow(2, "b"))
+
+		pageable, err := ScanPageable(rows, scanLabel, page, 42)
+
+		require.NoError(t, err)
+		assert.Equal(t, BuildPageable(page, 42, []label{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}), pageable)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no rows gives empty data", func(t *testing.T) {
+		rows, mock := query(t, sqlmock.NewRows([]string{"id", "name"}))
+
+		pageable, err := ScanPageable(rows, scanLabel, page, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, []label{}, pageable.Data)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("scan error is propagated", func(t *testing.T) {
+		rows, mock := query(t, sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a"))
+		errScan := errors.New("pouet")
+
+		_, err := ScanPageable(rows, func(*sql.Rows) (label, error) { return label{}, errScan }, page, 1)
+
+		assert.ErrorIs(t, err, errScan)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rows error is propagated", func(t *testing.T) {
+		errRows := errors.New("pouet")
+		rows, mock := query(t, sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a").AddRow(2, "b").RowError(1, errRows))
+
+		_, err := ScanPageable(rows, scanLabel, page, 2)
+
+		assert.ErrorIs(t, err, errRows)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
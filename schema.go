@@ -0,0 +1,41 @@
+package pagination
+
+// PageableSchema returns the OpenAPI schema object of a Pageable whose data items reference itemSchemaRef,
+// e.g. "#/components/schemas/Label". Pagination fields are nested in a meta object when ResponseFormat is FormatMeta
+// and data is named after DataFieldName
+func PageableSchema(itemSchemaRef string) map[string]interface{} {
+	fields := map[string]interface{}{
+		"limit":  map[string]interface{}{"type": "integer", "minimum": 0},
+		"offset": map[string]interface{}{"type": "integer", "minimum": 0},
+		"total":  map[string]interface{}{"type": "integer", "format": "int64"},
+	}
+	data := map[string]interface{}{
+		"type":  "array",
+		"items": map[string]interface{}{"$ref": itemSchemaRef},
+	}
+
+	if ResponseFormat == FormatMeta {
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []string{"meta", DataFieldName},
+			"properties": map[string]interface{}{
+				"meta": map[string]interface{}{
+					"type":       "object",
+					"required":   []string{"limit", "offset"},
+					"properties": fields,
+				},
+				DataFieldName: data,
+			},
+		}
+	}
+
+	properties := map[string]interface{}{DataFieldName: data}
+	for name, schema := range fields {
+		properties[name] = schema
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"required":   []string{"limit", "offset", DataFieldName},
+		"properties": properties,
+	}
+}
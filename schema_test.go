@@ -0,0 +1,42 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageableSchema(t *testing.T) {
+	const ref = "#/components/schemas/Label"
+
+	t.Run("flat format", func(t *testing.T) {
+		schema := PageableSchema(ref)
+
+		assert.Equal(t, "object", schema["type"])
+		properties, ok := schema["properties"].(map[string]interface{})
+		require.True(t, ok)
+		for _, field := range []string{"limit", "offset", "total"} {
+			assert.Contains(t, properties, field)
+		}
+		assert.Equal(t, map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"$ref": ref},
+		}, properties["data"])
+	})
+
+	t.Run("meta format", func(t *testing.T) {
+		format := ResponseFormat
+		t.Cleanup(func() { ResponseFormat = format })
+		ResponseFormat = FormatMeta
+
+		schema := PageableSchema(ref)
+
+		properties := schema["properties"].(map[string]interface{})
+		meta := properties["meta"].(map[string]interface{})["properties"].(map[string]interface{})
+		for _, field := range []string{"limit", "offset", "total"} {
+			assert.Contains(t, meta, field)
+		}
+		assert.Equal(t, ref, properties["data"].(map[string]interface{})["items"].(map[string]interface{})["$ref"])
+	})
+}
@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// FromPageToken builds pagination from an opaque page token and a page size, as used by gRPC list requests
+// An empty token is the first page and a page size of 0 means the package default limit
+// A page size higher than the package max limit is lowered to it
+func FromPageToken(token string, pageSize int32) (Pagination, error) {
+	if pageSize < 0 {
+		return Pagination{}, BadRequestValueError{Key: "page_size", Err: fmt.Errorf("page_size (%d) cannot be negative", pageSize)}
+	}
+
+	opts := DefaultOptions()
+	limit := int(pageSize)
+	if limit == 0 {
+		limit = opts.DefaultLimit
+	}
+
+	var offset int
+	if token != "" {
+		var err error
+		offset, err = decodeOffsetToken(token)
+		if err != nil {
+			return Pagination{}, BadRequestValueError{Key: "page_token", Err: err}
+		}
+	}
+
+	return applyLimitPolicy(Pagination{Offset: offset, Limit: limit}, pageSize != 0, "page_size", opts)
+}
+
+// ToPageToken returns the opaque token of the page following p, or an empty string if there is no next page
+func ToPageToken(p Pagination, total int64) string {
+	next := int64(p.Offset) + int64(p.Limit)
+	if p.Limit <= 0 || next >= total {
+		return ""
+	}
+	return encodeOffsetToken(int(next))
+}
+
+// encodeOffsetToken encodes offset into an opaque url safe token
+func encodeOffsetToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeOffsetToken decodes an offset from a token built by encodeOffsetToken
+func decodeOffsetToken(token string) (int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, fmt.Errorf("malformed token: %w", err)
+	}
+
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil {
+		return 0, fmt.Errorf("malformed token: %w", err)
+	}
+
+	// tokens are client supplied, so they get the same offset bounds as a query offset, MaxOffset included
+	if _, err := validateOffset(defaultQueryKeys.Offset, offset); err != nil {
+		var valueErr BadRequestValueError
+		if errors.As(err, &valueErr) {
+			err = valueErr.Err
+		}
+		return 0, fmt.Errorf("malformed token: %w", err)
+	}
+	return offset, nil
+}
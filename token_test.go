@@ -0,0 +1,91 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromPageToken(t *testing.T) {
+	tests := map[string]struct {
+		token    string
+		pageSize int32
+		want     Pagination
+		wantErr  bool
+	}{
+		"when token is empty, returns first page": {
+			pageSize: 20,
+			want:     Pagination{Offset: 0, Limit: 20},
+		},
+		"when page size is 0, returns default limit": {
+			want: Pagination{Offset: 0, Limit: DefaultLimit500},
+		},
+		"when page size is negative, returns error": {
+			pageSize: -1,
+			wantErr:  true,
+		},
+		"when token is not base64, returns error": {
+			token:    "%%%",
+			pageSize: 20,
+			wantErr:  true,
+		},
+		"when token does not hold an offset, returns error": {
+			token:    base64.RawURLEncoding.EncodeToString([]byte("pouet")),
+			pageSize: 20,
+			wantErr:  true,
+		},
+		"when token holds a negative offset, returns error": {
+			token:    base64.RawURLEncoding.EncodeToString([]byte("-20")),
+			pageSize: 20,
+			wantErr:  true,
+		},
+		"when token holds an offset over MaxOffset, returns error": {
+			token:    base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(MaxOffset+1, 10))),
+			pageSize: 20,
+			wantErr:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			page, err := FromPageToken(tt.token, tt.pageSize)
+			if tt.wantErr {
+				assert.ErrorAs(t, err, &BadRequestValueError{})
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, page)
+		})
+	}
+}
+
+func TestToPageToken(t *testing.T) {
+	t.Run("round trip walks through all pages", func(t *testing.T) {
+		total := int64(25)
+		page, err := FromPageToken("", 10)
+		require.NoError(t, err)
+
+		var offsets []int
+		for {
+			offsets = append(offsets, page.Offset)
+			token := ToPageToken(page, total)
+			if token == "" {
+				break
+			}
+			page, err = FromPageToken(token, 10)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, []int{0, 10, 20}, offsets)
+	})
+
+	t.Run("when page is the last one, returns empty token", func(t *testing.T) {
+		assert.Equal(t, "", ToPageToken(Pagination{Offset: 20, Limit: 10}, 30))
+	})
+
+	t.Run("when limit is 0, returns empty token", func(t *testing.T) {
+		assert.Equal(t, "", ToPageToken(Pagination{Offset: 0, Limit: 0}, 30))
+	})
+}